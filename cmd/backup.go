@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gstos/qbcli/internal/backup"
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup <dir|file.tar|file.tar.gz>",
+	Short: "Back up the .torrent files and metadata of all torrents",
+	Long: `Write the .torrent file of every torrent (or of those selected by the filter flags) and a
+manifest.json with their metadata (name, content layout, save path, category, tags, limits, state,
+added-on) into a directory, or into a tar archive when the destination ends with .tar, .tar.gz or .tgz.
+Torrents whose metadata is not available yet are saved as magnet links.
+Use 'qbcli restore' to add them back.`,
+	Example: `  qbcli backup /backups/qbittorrent-$(date +%F).tar.gz
+  qbcli backup ./linux-isos --category linux`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		torrents, err := selectTorrents(ctx, cli, cmd, nil)
+		if err != nil {
+			return err
+		}
+
+		w, err := backup.Create(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+
+		manifest := &backup.Manifest{
+			Version:   backup.ManifestVersion,
+			CreatedAt: time.Now().UTC(),
+			Host:      cli.BaseEndpoint(),
+		}
+
+		var errs []error
+		for _, t := range torrents {
+			entry := backup.Entry{
+				Hash:                     t.Hash,
+				Name:                     t.Name,
+				MagnetURI:                t.MagnetURI,
+				SavePath:                 t.SavePath,
+				DownloadPath:             t.DownloadPath,
+				ContentLayout:            contentLayout(t),
+				Category:                 t.Category,
+				Tags:                     t.TagList(),
+				AutoTMM:                  t.AutoTMM,
+				Stopped:                  slices.Contains(stoppedStates, t.State),
+				RatioLimit:               t.RatioLimit,
+				SeedingTimeLimit:         t.SeedingTimeLimit,
+				InactiveSeedingTimeLimit: t.InactiveSeedingTimeLimit,
+				UpLimit:                  t.UpLimit,
+				DlLimit:                  t.DlLimit,
+				AddedOn:                  t.AddedOn,
+			}
+
+			content, err := cli.ExportTorrent(ctx, t.Hash)
+			switch {
+			case err == nil:
+				entry.File = backup.TorrentPath(t.Hash)
+				if err := w.WriteFile(entry.File, content); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", t.Name, err))
+					continue
+				}
+			case t.MagnetURI != "":
+				cli.Log.Warn("torrent export failed, saving magnet link only", "name", t.Name, "hash", t.Hash, "error", err)
+			default:
+				errs = append(errs, fmt.Errorf("%s: %w", t.Name, err))
+				continue
+			}
+
+			manifest.Torrents = append(manifest.Torrents, entry)
+		}
+
+		if err := backup.WriteManifest(w, manifest); err != nil {
+			errs = append(errs, err)
+		}
+		if err := w.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing backup: %w", err))
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to back up torrents: %w", errors.Join(errs...))
+		}
+
+		cli.Log.Info("Torrents backed up successfully", "count", len(manifest.Torrents), "dest", args[0])
+		return nil
+	},
+}
+
+// contentLayout tells the layout a torrent was added with from its paths, since qBittorrent does
+// not report it: a root folder means Subfolder (which keeps an existing one), content right in the
+// save path NoSubfolder, and anything else (such as a single file) Original. It is unknown without metadata.
+func contentLayout(t client.Torrent) string {
+	switch {
+	case t.State == "metaDL" || t.State == "forcedMetaDL":
+		return ""
+	case t.RootPath != "":
+		return "Subfolder"
+	case samePath(t.ContentPath, t.SavePath) || t.DownloadPath != "" && samePath(t.ContentPath, t.DownloadPath):
+		return "NoSubfolder"
+	default:
+		return "Original"
+	}
+}
+
+func init() {
+	addTorrentFilterFlags(backupCmd)
+	rootCmd.AddCommand(backupCmd)
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+)
+
+func TestContentLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		torrent client.Torrent
+		want    string
+	}{
+		{"root folder", client.Torrent{SavePath: "/data", RootPath: "/data/show", ContentPath: "/data/show"}, "Subfolder"},
+		{"no root folder", client.Torrent{SavePath: "/data/", ContentPath: "/data"}, "NoSubfolder"},
+		{"incomplete without root folder", client.Torrent{SavePath: "/data", DownloadPath: "/incomplete",
+			ContentPath: "/incomplete"}, "NoSubfolder"},
+		{"single file", client.Torrent{SavePath: "/data", ContentPath: "/data/movie.mkv"}, "Original"},
+		{"no metadata", client.Torrent{State: "metaDL", SavePath: "/data", ContentPath: "/data"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := contentLayout(tt.torrent); got != tt.want {
+			t.Errorf("%s: contentLayout() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
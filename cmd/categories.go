@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/spf13/cobra"
+)
+
+var categoriesCmd = &cobra.Command{
+	Use:     "categories",
+	Aliases: []string{"category"},
+	Short:   "Manage torrent categories",
+}
+
+var categoriesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List categories and their save paths",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		categories, err := cli.Categories(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list categories: %w", err)
+		}
+
+		if format == outputJSON {
+			return printJSON(categories)
+		}
+
+		names := make([]string, 0, len(categories))
+		for name := range categories {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		w := newTable()
+		_, _ = fmt.Fprintln(w, "NAME\tSAVE PATH")
+		for _, name := range names {
+			_, _ = fmt.Fprintf(w, "%s\t%s\n", name, categories[name].SavePath)
+		}
+		return w.Flush()
+	},
+}
+
+var categoriesCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a category",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		savePath, _ := cmd.Flags().GetString("save-path")
+		if err := cli.CreateCategory(ctx, args[0], savePath); err != nil {
+			return fmt.Errorf("failed to create category: %w", err)
+		}
+
+		cli.Log.Info("Category created successfully", "category", args[0], "savePath", savePath)
+		return nil
+	},
+}
+
+var categoriesEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Change the save path of a category",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		savePath, _ := cmd.Flags().GetString("save-path")
+		if err := cli.EditCategory(ctx, args[0], savePath); err != nil {
+			return fmt.Errorf("failed to edit category: %w", err)
+		}
+
+		cli.Log.Info("Category edited successfully", "category", args[0], "savePath", savePath)
+		return nil
+	},
+}
+
+var categoriesRemoveCmd = &cobra.Command{
+	Use:   "remove <name>...",
+	Short: "Remove categories",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.RemoveCategories(ctx, args); err != nil {
+			return fmt.Errorf("failed to remove categories: %w", err)
+		}
+
+		cli.Log.Info("Categories removed successfully", "categories", args)
+		return nil
+	},
+}
+
+var categoriesAssignCmd = newTorrentsActionCmd("assign", "Assign a category to torrents (use --name '' to clear it)", nil,
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		return cli.SetCategory(ctx, hashes, name)
+	})
+
+func init() {
+	addOutputFlag(categoriesListCmd)
+	categoriesCreateCmd.Flags().String("save-path", "", "Save path of the category (empty for default)")
+	categoriesEditCmd.Flags().String("save-path", "", "New save path of the category ('' for default)")
+	_ = categoriesEditCmd.MarkFlagRequired("save-path")
+	categoriesAssignCmd.Flags().String("name", "", "Category to assign")
+	_ = categoriesAssignCmd.MarkFlagRequired("name")
+
+	categoriesCmd.AddCommand(categoriesListCmd, categoriesCreateCmd, categoriesEditCmd, categoriesRemoveCmd, categoriesAssignCmd)
+	rootCmd.AddCommand(categoriesCmd)
+}
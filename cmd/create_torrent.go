@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/units"
+	"github.com/spf13/cobra"
+)
+
+var createTorrentCmd = &cobra.Command{
+	Use:   "create-torrent <path>",
+	Short: "Create a .torrent file with the qBittorrent torrent creator",
+	Long: `Create a .torrent file from a file or folder using the torrent creator (qBittorrent 5.0+).
+The path refers to the host qBittorrent runs on. The command waits for the task to finish,
+showing its progress, and downloads the resulting .torrent file to --output.
+With --seed, qBittorrent adds the new torrent and starts seeding it from the source path.`,
+	Example: `  qbcli create-torrent /data/share/dataset --tracker udp://tracker.example.org:1337/announce --private --seed
+  qbcli create-torrent /data/share/iso --format hybrid --piece-size 4MiB -O iso.torrent`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rootCtx, cancel := rootEnv.Context()
+		defer cancel()
+
+		ctx, stop := signal.NotifyContext(rootCtx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		flags := cmd.Flags()
+		output, _ := flags.GetString("output")
+		interval, _ := flags.GetDuration("interval")
+		keepTask, _ := flags.GetBool("keep-task")
+
+		if interval <= 0 {
+			return fmt.Errorf("invalid interval: %s", interval)
+		}
+
+		opts := client.CreateTorrentOptions{SourcePath: args[0]}
+		opts.Private, _ = flags.GetBool("private")
+		opts.StartSeeding, _ = flags.GetBool("seed")
+		opts.Comment, _ = flags.GetString("comment")
+		opts.Source, _ = flags.GetString("source")
+		opts.Trackers, _ = flags.GetStringSlice("tracker")
+		opts.URLSeeds, _ = flags.GetStringSlice("web-seed")
+
+		format, _ := flags.GetString("format")
+		switch strings.ToLower(format) {
+		case "", "v1", "v2", "hybrid":
+			opts.Format = strings.ToLower(format)
+		default:
+			return fmt.Errorf("invalid torrent format: %s", format)
+		}
+
+		if value, _ := flags.GetString("piece-size"); value != "" {
+			size, err := units.ParseBytes(value)
+			if err != nil {
+				return err
+			}
+			if size < 0 || size&(size-1) != 0 {
+				return fmt.Errorf("invalid piece size: %s (must be a power of two)", value)
+			}
+			opts.PieceSize = size
+		}
+
+		if output == "" {
+			output = path.Base(strings.TrimRight(strings.ReplaceAll(args[0], "\\", "/"), "/")) + ".torrent"
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		taskID, err := cli.AddTorrentCreatorTask(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to create torrent: %w", err)
+		}
+		if !keepTask {
+			defer func() {
+				if err := cli.DeleteTorrentCreatorTask(rootCtx, taskID); err != nil {
+					cli.Log.Warn("failed to delete torrent creator task", "taskID", taskID, "error", err)
+				}
+			}()
+		}
+
+		task, err := waitForTorrentCreator(ctx, cli, taskID, interval)
+		if err != nil {
+			return err
+		}
+		if task.Status == client.CreatorFailed {
+			return fmt.Errorf("failed to create torrent: %s", task.ErrorMessage)
+		}
+
+		content, err := cli.TorrentCreatorFile(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("failed to download torrent file: %w", err)
+		}
+
+		if output == "-" {
+			_, err = os.Stdout.Write(content)
+		} else {
+			err = os.WriteFile(output, content, 0o644)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write torrent file: %w", err)
+		}
+
+		cli.Log.Info("Torrent created successfully", "output", output, "pieceSize", task.PieceSize, "seeding", opts.StartSeeding)
+		return nil
+	},
+}
+
+// waitForTorrentCreator polls the task until it finishes or fails, reporting progress on stderr.
+func waitForTorrentCreator(ctx context.Context, cli *client.Client, taskID string, interval time.Duration) (client.TorrentCreatorTask, error) {
+	tty := isTerminal(os.Stderr)
+	lastProgress := -1.0
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		task, err := cli.TorrentCreatorStatus(ctx, taskID)
+		if err != nil {
+			return task, fmt.Errorf("failed to get torrent creator status: %w", err)
+		}
+
+		if task.Progress != lastProgress || task.IsDone() {
+			if tty {
+				_, _ = fmt.Fprintf(os.Stderr, "\r%-8s %5.1f%%", task.Status, task.Progress)
+				if task.IsDone() {
+					_, _ = fmt.Fprintln(os.Stderr)
+				}
+			} else {
+				_, _ = fmt.Fprintf(os.Stderr, "%s %.1f%%\n", task.Status, task.Progress)
+			}
+			lastProgress = task.Progress
+		}
+
+		if task.IsDone() {
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func init() {
+	flags := createTorrentCmd.Flags()
+	flags.StringSlice("tracker", nil, "Tracker URL (repeatable)")
+	flags.StringSlice("web-seed", nil, "Web seed URL (repeatable)")
+	flags.String("piece-size", "", "Piece size (e.g. 256KiB, 4MiB); automatic when empty")
+	flags.String("format", "", "Torrent format: v1, v2 or hybrid (server default when empty)")
+	flags.Bool("private", false, "Create a private torrent")
+	flags.String("comment", "", "Torrent comment")
+	flags.String("source", "", "Torrent source field")
+	flags.Bool("seed", false, "Add the created torrent to qBittorrent and start seeding")
+	flags.StringP("output", "O", "", "Where to write the .torrent file ('-' for stdout; defaults to <name>.torrent)")
+	flags.Duration("interval", time.Second, "Status polling interval")
+	flags.Bool("keep-task", false, "Keep the finished task in qBittorrent")
+	rootCmd.AddCommand(createTorrentCmd)
+}
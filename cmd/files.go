@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/units"
+	"github.com/spf13/cobra"
+)
+
+var filesCmd = &cobra.Command{
+	Use:   "files",
+	Short: "Manage files within torrents",
+}
+
+var filesListCmd = &cobra.Command{
+	Use:   "list <hash>",
+	Short: "List files of a torrent with progress and priority",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		files, err := cli.Files(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+
+		if format == outputJSON {
+			return printJSON(files)
+		}
+
+		w := newTable()
+		_, _ = fmt.Fprintln(w, "INDEX\tPRIORITY\tPROGRESS\tSIZE\tNAME")
+		for _, f := range files {
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+				f.Index, client.PriorityString(f.Priority), units.FormatPercent(f.Progress), units.FormatBytes(f.Size), f.Name)
+		}
+		return w.Flush()
+	},
+}
+
+// filePriorityFlags are evaluated in order, so later entries win when several patterns match a file.
+var filePriorityFlags = []struct {
+	flag     string
+	priority int
+	usage    string
+}{
+	{"normal", client.PriorityNormal, "Glob of files to download with normal priority"},
+	{"high", client.PriorityHigh, "Glob of files to download with high priority"},
+	{"max", client.PriorityMaximum, "Glob of files to download with maximum priority"},
+	{"skip", client.PriorityDoNotDownload, "Glob of files not to download (e.g. '*.nfo')"},
+}
+
+var filesPriorityCmd = &cobra.Command{
+	Use:     "priority [hash...|all]",
+	Aliases: []string{"prio"},
+	Short:   "Set file priorities by glob pattern across torrents",
+	Long: `Set file priorities by glob pattern across torrents.
+Patterns are matched against both the full path within the torrent and the file name.
+When several patterns match a file, --skip wins over --max, --high and --normal.
+All torrents are scanned unless hashes or the --filter, --category and --tag flags are given.`,
+	Example: `  qbcli files priority --skip '*.nfo' --skip '*sample*'
+  qbcli files priority <hash> --high '*.mkv' --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		patterns := map[int][]string{}
+		for _, f := range filePriorityFlags {
+			globs, _ := cmd.Flags().GetStringArray(f.flag)
+			for _, glob := range globs {
+				if _, err := path.Match(glob, ""); err != nil {
+					return fmt.Errorf("invalid pattern %q: %w", glob, err)
+				}
+			}
+			patterns[f.priority] = globs
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		torrents, err := selectTorrents(ctx, cli, cmd, args)
+		if err != nil {
+			return err
+		}
+
+		w := newTable()
+		_, _ = fmt.Fprintln(w, "HASH\tFILE\tPRIORITY")
+
+		var errs []error
+		changed := 0
+		for _, t := range torrents {
+			files, err := cli.Files(ctx, t.Hash)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", t.Hash, err))
+				continue
+			}
+
+			byPriority := map[int][]int{}
+			for _, file := range files {
+				priority, ok := matchFilePriority(file.Name, patterns)
+				if !ok || priority == file.Priority {
+					continue
+				}
+				byPriority[priority] = append(byPriority[priority], file.Index)
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s -> %s\n", t.Hash[:min(len(t.Hash), 8)], file.Name,
+					client.PriorityString(file.Priority), client.PriorityString(priority))
+			}
+
+			for priority, indexes := range byPriority {
+				changed += len(indexes)
+				if dryRun {
+					continue
+				}
+				if err := cli.SetFilePriority(ctx, t.Hash, indexes, priority); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", t.Hash, err))
+				}
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to set some file priorities: %w", errors.Join(errs...))
+		}
+
+		cli.Log.Info("File priorities updated successfully", "torrents", len(torrents), "files", changed, "dryRun", dryRun)
+		return nil
+	},
+}
+
+func matchFilePriority(name string, patterns map[int][]string) (int, bool) {
+	priority, found := 0, false
+	for _, f := range filePriorityFlags {
+		for _, glob := range patterns[f.priority] {
+			fullMatch, _ := path.Match(glob, name)
+			baseMatch, _ := path.Match(glob, path.Base(name))
+			if fullMatch || baseMatch {
+				priority, found = f.priority, true
+			}
+		}
+	}
+	return priority, found
+}
+
+var filesRenameCmd = &cobra.Command{
+	Use:   "rename <hash> <old-path> <new-path>",
+	Short: "Rename a file within a torrent",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.RenameFile(ctx, args[0], args[1], args[2]); err != nil {
+			return fmt.Errorf("failed to rename file: %w", err)
+		}
+
+		cli.Log.Info("File renamed successfully", "hash", args[0], "newPath", args[2])
+		return nil
+	},
+}
+
+var filesRenameFolderCmd = &cobra.Command{
+	Use:   "rename-folder <hash> <old-path> <new-path>",
+	Short: "Rename a folder within a torrent",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.RenameFolder(ctx, args[0], args[1], args[2]); err != nil {
+			return fmt.Errorf("failed to rename folder: %w", err)
+		}
+
+		cli.Log.Info("Folder renamed successfully", "hash", args[0], "newPath", args[2])
+		return nil
+	},
+}
+
+func init() {
+	addOutputFlag(filesListCmd)
+
+	for _, f := range filePriorityFlags {
+		filesPriorityCmd.Flags().StringArray(f.flag, nil, f.usage)
+	}
+	filesPriorityCmd.MarkFlagsOneRequired("skip", "high", "max", "normal")
+	filesPriorityCmd.Flags().Bool("dry-run", false, "Only report what would change")
+	addTorrentFilterFlags(filesPriorityCmd)
+
+	filesCmd.AddCommand(filesListCmd, filesPriorityCmd, filesRenameCmd, filesRenameFolderCmd)
+	rootCmd.AddCommand(filesCmd)
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/units"
+	"github.com/spf13/cobra"
+)
+
+var limitsCmd = &cobra.Command{
+	Use:   "limits",
+	Short: "Manage per-torrent share and speed limits",
+}
+
+type torrentLimits struct {
+	Hash string `json:"hash"`
+	Name string `json:"name"`
+	client.ShareLimits
+	DlLimit int64 `json:"dl_limit"`
+	UpLimit int64 `json:"up_limit"`
+}
+
+var limitsListCmd = &cobra.Command{
+	Use:   "list [hash...|all]",
+	Short: "Show the share and speed limits of torrents",
+	Long: `Show the ratio, seeding time and inactive seeding time limits and the speed limits of torrents.
+Torrents are selected by hash or by the --filter, --category and --tag flags; all torrents by default.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		torrents, err := selectTorrents(ctx, cli, cmd, args)
+		if err != nil {
+			return err
+		}
+
+		if format == outputJSON {
+			limits := make([]torrentLimits, 0, len(torrents))
+			for _, t := range torrents {
+				limits = append(limits, torrentLimits{
+					Hash:        t.Hash,
+					Name:        t.Name,
+					ShareLimits: t.ShareLimits(),
+					DlLimit:     t.DlLimit,
+					UpLimit:     t.UpLimit,
+				})
+			}
+			return printJSON(limits)
+		}
+
+		w := newTable()
+		_, _ = fmt.Fprintln(w, "HASH\tNAME\tRATIO\tSEEDING TIME\tINACTIVE TIME\tDOWN\tUP")
+		for _, t := range torrents {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				t.Hash[:min(len(t.Hash), 8)],
+				truncate(t.Name, 50),
+				formatRatioLimit(t.RatioLimit),
+				formatTimeLimit(t.SeedingTimeLimit),
+				formatTimeLimit(t.InactiveSeedingTimeLimit),
+				formatRateLimit(t.DlLimit),
+				formatRateLimit(t.UpLimit),
+			)
+		}
+		return w.Flush()
+	},
+}
+
+var limitsSetCmd = &cobra.Command{
+	Use:   "set [hash...|all]",
+	Short: "Set the share and speed limits of torrents",
+	Long: `Set the share and speed limits of torrents selected by hash, by the keyword 'all',
+or by the --filter, --category and --tag flags.
+Share limits not given keep their current value on each torrent.`,
+	Example: `  qbcli limits set --tag private-tracker --ratio unlimited --seeding-time 336h
+  qbcli limits set --category public --ratio 1.5 --inactive-seeding-time 24h --up 500KiB
+  qbcli limits set <hash> --ratio global --seeding-time global --down 0`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		flags := cmd.Flags()
+
+		var ratio *float64
+		if flags.Changed("ratio") {
+			value, _ := flags.GetString("ratio")
+			limit, err := parseRatioLimit(value)
+			if err != nil {
+				return err
+			}
+			ratio = &limit
+		}
+
+		parseTime := func(name string) (*int64, error) {
+			if !flags.Changed(name) {
+				return nil, nil
+			}
+			value, _ := flags.GetString(name)
+			minutes, err := parseTimeLimit(value)
+			if err != nil {
+				return nil, err
+			}
+			limit := int64(minutes)
+			return &limit, nil
+		}
+
+		seedingTime, err := parseTime("seeding-time")
+		if err != nil {
+			return err
+		}
+		inactiveTime, err := parseTime("inactive-seeding-time")
+		if err != nil {
+			return err
+		}
+
+		parseRate := func(name string) (*int64, error) {
+			if !flags.Changed(name) {
+				return nil, nil
+			}
+			value, _ := flags.GetString(name)
+			limit, err := units.ParseRate(value)
+			if err != nil {
+				return nil, err
+			}
+			return &limit, nil
+		}
+
+		down, err := parseRate("down")
+		if err != nil {
+			return err
+		}
+		up, err := parseRate("up")
+		if err != nil {
+			return err
+		}
+
+		if ratio == nil && seedingTime == nil && inactiveTime == nil && down == nil && up == nil {
+			return fmt.Errorf("nothing to set: use --ratio, --seeding-time, --inactive-seeding-time, --down or --up")
+		}
+
+		if len(args) == 0 && !hasTorrentFilter(cmd) {
+			return fmt.Errorf("no torrents selected: pass hashes, 'all' or filter flags")
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		torrents, err := selectTorrents(ctx, cli, cmd, args)
+		if err != nil {
+			return err
+		}
+
+		if len(torrents) == 0 {
+			cli.Log.Warn("No torrents matched the selection")
+			return nil
+		}
+
+		var errs []error
+		if ratio != nil || seedingTime != nil || inactiveTime != nil {
+			// setShareLimits takes all three limits, so torrents are grouped by their resulting limits
+			groups := map[client.ShareLimits][]string{}
+			for _, t := range torrents {
+				limits := t.ShareLimits()
+				if ratio != nil {
+					limits.RatioLimit = *ratio
+				}
+				if seedingTime != nil {
+					limits.SeedingTimeLimit = *seedingTime
+				}
+				if inactiveTime != nil {
+					limits.InactiveSeedingTimeLimit = *inactiveTime
+				}
+				groups[limits] = append(groups[limits], t.Hash)
+			}
+
+			for limits, hashes := range groups {
+				if err := cli.SetShareLimits(ctx, hashes, limits); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+
+		hashes := make([]string, 0, len(torrents))
+		for _, t := range torrents {
+			hashes = append(hashes, t.Hash)
+		}
+
+		if down != nil {
+			if err := cli.SetTorrentDownloadLimit(ctx, hashes, *down); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if up != nil {
+			if err := cli.SetTorrentUploadLimit(ctx, hashes, *up); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to set limits: %w", errors.Join(errs...))
+		}
+
+		cli.Log.Info("Limits set successfully", "count", len(torrents))
+		return nil
+	},
+}
+
+func init() {
+	addTorrentFilterFlags(limitsListCmd)
+	addOutputFlag(limitsListCmd)
+
+	flags := limitsSetCmd.Flags()
+	flags.String("ratio", "", "Share ratio limit: a number, 'global' or 'unlimited'")
+	flags.String("seeding-time", "", "Seeding time limit: a duration (e.g. 72h), 'global' or 'unlimited'")
+	flags.String("inactive-seeding-time", "", "Inactive seeding time limit: a duration (e.g. 24h), 'global' or 'unlimited'")
+	flags.String("down", "", "Download speed limit (e.g. 5MiB, 0 for unlimited)")
+	flags.String("up", "", "Upload speed limit (e.g. 1MiB, 0 for unlimited)")
+	addTorrentFilterFlags(limitsSetCmd)
+
+	limitsCmd.AddCommand(limitsListCmd, limitsSetCmd)
+	rootCmd.AddCommand(limitsCmd)
+}
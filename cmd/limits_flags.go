@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/units"
+)
+
+// qBittorrent encodes share limits as numbers with two special values.
+const (
+	limitUseGlobal = client.ShareLimitGlobal
+	limitUnlimited = client.ShareLimitUnlimited
+)
+
+func parseRatioLimit(value string) (float64, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "global":
+		return limitUseGlobal, nil
+	case "unlimited", "none":
+		return limitUnlimited, nil
+	}
+
+	ratio, err := strconv.ParseFloat(value, 64)
+	if err != nil || ratio < 0 {
+		return 0, fmt.Errorf("invalid ratio limit: %s", value)
+	}
+	return ratio, nil
+}
+
+// parseTimeLimit returns the limit in minutes, as expected by qBittorrent. Durations that are not
+// whole minutes are refused rather than truncated, since a limit of 0 stops torrents at once.
+func parseTimeLimit(value string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "global":
+		return limitUseGlobal, nil
+	case "unlimited", "none":
+		return limitUnlimited, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid time limit: %s", value)
+	}
+	if d%time.Minute != 0 {
+		return 0, fmt.Errorf("invalid time limit: %s (must be whole minutes)", value)
+	}
+	return int(d / time.Minute), nil
+}
+
+func formatRatioLimit(ratio float64) string {
+	switch ratio {
+	case limitUseGlobal:
+		return "global"
+	case limitUnlimited:
+		return "unlimited"
+	}
+	return strconv.FormatFloat(ratio, 'f', 2, 64)
+}
+
+func formatTimeLimit(minutes int64) string {
+	switch minutes {
+	case limitUseGlobal:
+		return "global"
+	case limitUnlimited:
+		return "unlimited"
+	}
+	return units.FormatDuration(time.Duration(minutes) * time.Minute)
+}
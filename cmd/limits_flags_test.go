@@ -0,0 +1,26 @@
+package cmd
+
+import "testing"
+
+func TestParseTimeLimit(t *testing.T) {
+	tests := map[string]int{
+		"global":    limitUseGlobal,
+		"unlimited": limitUnlimited,
+		"0":         0,
+		"90m":       90,
+		"336h":      336 * 60,
+		"1h30m":     90,
+	}
+	for in, want := range tests {
+		got, err := parseTimeLimit(in)
+		if err != nil || got != want {
+			t.Errorf("parseTimeLimit(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"", "30s", "1m30s", "-1h", "soon"} {
+		if _, err := parseTimeLimit(in); err == nil {
+			t.Errorf("parseTimeLimit(%q) expected error", in)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/spf13/cobra"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show the qBittorrent log",
+	Long: `Show the qBittorrent main log, or the peer log with --peers.
+With --follow, new entries are printed as they appear until interrupted.
+JSON output is newline-delimited, one entry per line.`,
+	Example: `  qbcli log --severity warning,critical
+  qbcli log --follow --severity info | grep -i 'listen'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rootCtx, cancel := rootEnv.Context()
+		defer cancel()
+
+		ctx, stop := signal.NotifyContext(rootCtx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		flags := cmd.Flags()
+		follow, _ := flags.GetBool("follow")
+		interval, _ := flags.GetDuration("interval")
+		peers, _ := flags.GetBool("peers")
+		severities, _ := flags.GetStringSlice("severity")
+
+		if follow && interval <= 0 {
+			return fmt.Errorf("invalid interval: %s", interval)
+		}
+
+		if peers && flags.Changed("severity") {
+			return fmt.Errorf("use either --peers or --severity: the peer log has no severities")
+		}
+
+		opts := client.MainLogOptions{LastKnownID: -1}
+		for _, severity := range severities {
+			switch strings.ToLower(severity) {
+			case "normal":
+				opts.Normal = true
+			case "info":
+				opts.Info = true
+			case "warning":
+				opts.Warning = true
+			case "critical":
+				opts.Critical = true
+			default:
+				return fmt.Errorf("invalid severity: %s", severity)
+			}
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		fetch := mainLogFetcher(cli, opts)
+		if peers {
+			fetch = peerLogFetcher(cli)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		last := logLine{id: -1}
+
+		for {
+			var lines []logLine
+			lines, last, err = fetchNewLogLines(ctx, fetch, last)
+			switch {
+			case err != nil && (!follow || ctx.Err() != nil):
+				return logFetchError(ctx, err)
+			case err != nil:
+				cli.Log.Warn("failed to get log, retrying", "error", err)
+			}
+
+			for _, line := range lines {
+				if format == outputJSON {
+					err = encoder.Encode(line.entry)
+				} else {
+					_, err = fmt.Println(line.text)
+				}
+				if err != nil {
+					return fmt.Errorf("failed to write log entry: %w", err)
+				}
+			}
+
+			if !follow {
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(interval):
+			}
+		}
+	},
+}
+
+// logLine is a main or peer log entry, with its ID and its text output.
+type logLine struct {
+	id    int
+	entry any
+	text  string
+}
+
+// logFetcher returns the log entries after lastKnownID (-1 for all).
+type logFetcher func(ctx context.Context, lastKnownID int) ([]logLine, error)
+
+func mainLogFetcher(cli *client.Client, opts client.MainLogOptions) logFetcher {
+	return func(ctx context.Context, lastKnownID int) ([]logLine, error) {
+		opts.LastKnownID = lastKnownID
+		entries, err := cli.MainLog(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		lines := make([]logLine, len(entries))
+		for i, e := range entries {
+			text := fmt.Sprintf("%s  %-8s  %s", formatLogTime(e.Timestamp), strings.ToUpper(e.Severity()), e.Message)
+			lines[i] = logLine{id: e.ID, entry: e, text: text}
+		}
+		return lines, nil
+	}
+}
+
+func peerLogFetcher(cli *client.Client) logFetcher {
+	return func(ctx context.Context, lastKnownID int) ([]logLine, error) {
+		entries, err := cli.PeerLog(ctx, lastKnownID)
+		if err != nil {
+			return nil, err
+		}
+		lines := make([]logLine, len(entries))
+		for i, e := range entries {
+			status := "allowed"
+			if e.Blocked {
+				status = "blocked: " + e.Reason
+			}
+			text := fmt.Sprintf("%s  %s  %s", formatLogTime(e.Timestamp), e.IP, status)
+			lines[i] = logLine{id: e.ID, entry: e, text: text}
+		}
+		return lines, nil
+	}
+}
+
+// fetchNewLogLines returns the entries after the last seen one, and the new last seen entry
+// (with ID -1 before any). It asks for the last seen entry again: when it is gone or differs,
+// qBittorrent was restarted and numbers its log from 0 again, so the whole log is new.
+// On error, last is returned unchanged.
+func fetchNewLogLines(ctx context.Context, fetch logFetcher, last logLine) ([]logLine, logLine, error) {
+	lines, err := fetch(ctx, max(last.id-1, -1))
+	if err != nil {
+		return nil, last, err
+	}
+
+	if last.id >= 0 && !slices.ContainsFunc(lines, func(line logLine) bool {
+		return line.id == last.id && line.text == last.text
+	}) {
+		last = logLine{id: -1}
+		if lines, err = fetch(ctx, -1); err != nil {
+			return nil, last, err
+		}
+	}
+
+	var fresh []logLine
+	for _, line := range lines {
+		if line.id <= last.id {
+			continue
+		}
+		fresh = append(fresh, line)
+		last = line
+	}
+	return fresh, last, nil
+}
+
+func logFetchError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return nil
+	}
+	return fmt.Errorf("failed to get log: %w", err)
+}
+
+// formatLogTime handles both the seconds and the milliseconds timestamps used by different qBittorrent releases.
+func formatLogTime(timestamp int64) string {
+	if timestamp > 1e12 {
+		return time.UnixMilli(timestamp).Format("2006-01-02 15:04:05")
+	}
+	return time.Unix(timestamp, 0).Format("2006-01-02 15:04:05")
+}
+
+func init() {
+	logCmd.Flags().BoolP("follow", "f", false, "Keep polling for new entries")
+	logCmd.Flags().Duration("interval", 2*time.Second, "Polling interval for --follow")
+	logCmd.Flags().Bool("peers", false, "Show the peer log instead of the main log")
+	logCmd.Flags().StringSlice("severity", []string{"normal", "info", "warning", "critical"}, "Severities to show: normal, info, warning, critical")
+	addOutputFlag(logCmd)
+	rootCmd.AddCommand(logCmd)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fakeLog serves its lines, numbered from 0, after the last known ID as qBittorrent does.
+type fakeLog struct {
+	texts []string
+	err   error
+}
+
+func (l *fakeLog) fetch(ctx context.Context, lastKnownID int) ([]logLine, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	var lines []logLine
+	for id, text := range l.texts {
+		if id > lastKnownID {
+			lines = append(lines, logLine{id: id, text: text})
+		}
+	}
+	return lines, nil
+}
+
+func lineTexts(lines []logLine) []string {
+	texts := []string{}
+	for _, line := range lines {
+		texts = append(texts, line.text)
+	}
+	return texts
+}
+
+func TestFetchNewLogLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		texts    []string
+		last     logLine
+		want     []string
+		wantLast logLine
+	}{
+		{name: "first", texts: []string{"a", "b", "c"}, last: logLine{id: -1},
+			want: []string{"a", "b", "c"}, wantLast: logLine{id: 2, text: "c"}},
+		{name: "empty", last: logLine{id: -1}, want: []string{}, wantLast: logLine{id: -1}},
+		{name: "new", texts: []string{"a", "b", "c", "d", "e"}, last: logLine{id: 2, text: "c"},
+			want: []string{"d", "e"}, wantLast: logLine{id: 4, text: "e"}},
+		{name: "nothing new", texts: []string{"a", "b", "c"}, last: logLine{id: 2, text: "c"},
+			want: []string{}, wantLast: logLine{id: 2, text: "c"}},
+		{name: "restarted", texts: []string{"x", "y"}, last: logLine{id: 7, text: "h"},
+			want: []string{"x", "y"}, wantLast: logLine{id: 1, text: "y"}},
+		{name: "restarted empty", last: logLine{id: 7, text: "h"}, want: []string{}, wantLast: logLine{id: -1}},
+		{name: "restarted past last", texts: []string{"v", "w", "x", "y", "z"}, last: logLine{id: 2, text: "c"},
+			want: []string{"v", "w", "x", "y", "z"}, wantLast: logLine{id: 4, text: "z"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := &fakeLog{texts: tt.texts}
+			lines, last, err := fetchNewLogLines(context.Background(), log.fetch, tt.last)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := lineTexts(lines); !reflect.DeepEqual(got, tt.want) || last != tt.wantLast {
+				t.Errorf("fetchNewLogLines() = %v, %+v; want %v, %+v", got, last, tt.want, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestFetchNewLogLinesError(t *testing.T) {
+	log := &fakeLog{err: errors.New("connection refused")}
+	last := logLine{id: 5, text: "e"}
+	lines, got, err := fetchNewLogLines(context.Background(), log.fetch, last)
+	if err == nil || lines != nil || got != last {
+		t.Errorf("fetchNewLogLines() = %v, %+v, %v; want the error and the last line unchanged", lines, got, err)
+	}
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/spf13/cobra"
+)
+
+var networkCmd = &cobra.Command{
+	Use:   "network",
+	Short: "Network interface binding",
+}
+
+// Special addresses offered by qBittorrent besides the interface's own.
+var wildcardAddresses = []string{"0.0.0.0", "::"}
+
+var networkBindCmd = &cobra.Command{
+	Use:   "bind --interface <name>",
+	Short: "Bind qBittorrent to a network interface",
+	Long: `Bind qBittorrent to a network interface, and optionally to one of its addresses,
+so that no traffic leaves through another interface (e.g. when the VPN tunnel is down).
+The interface and address are validated against the ones qBittorrent reports.
+Nothing is written when the binding is already set; otherwise it is read back to verify it.
+With --check, nothing is changed and the command fails if qBittorrent is bound elsewhere,
+or if the interface (or the address) is not available, e.g. because the tunnel is down;
+the address is only compared when --address is given.`,
+	Example: `  qbcli network bind --interface tun0
+  qbcli network bind --interface tun0 --address 10.2.0.2
+  qbcli network bind --interface tun0 --check`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		flags := cmd.Flags()
+		iface, _ := flags.GetString("interface")
+		address, _ := flags.GetString("address")
+		check, _ := flags.GetBool("check")
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if check {
+			if err := checkNetworkBinding(ctx, cli, iface, address, flags.Changed("address")); err != nil {
+				return err
+			}
+			cli.Log.Info("Network binding verified", "interface", iface)
+			return nil
+		}
+
+		want, err := resolveNetworkBinding(ctx, cli, iface, address)
+		if err != nil {
+			return err
+		}
+
+		changed, err := cli.EnsureNetworkBinding(ctx, want)
+		if err != nil {
+			return fmt.Errorf("failed to bind network interface: %w", err)
+		}
+
+		if !changed {
+			cli.Log.Info("Network binding already set", "interface", want.Interface, "address", want.Address)
+			return nil
+		}
+
+		cli.Log.Info("Network interface bound successfully", "interface", want.Interface, "address", want.Address)
+		return nil
+	},
+}
+
+// errNotAvailable marks interfaces and addresses that qBittorrent does not report, such as
+// those of a tunnel that is down.
+var errNotAvailable = errors.New("not available")
+
+// resolveNetworkBinding checks the interface and address against the ones qBittorrent reports.
+// The interface may be given by name or by value; the binding uses the value.
+func resolveNetworkBinding(ctx context.Context, cli *client.Client, name string, address string) (client.NetworkBinding, error) {
+	ifaces, err := cli.NetworkInterfaces(ctx)
+	if err != nil {
+		return client.NetworkBinding{}, fmt.Errorf("failed to get network interfaces: %w", err)
+	}
+
+	idx := slices.IndexFunc(ifaces, func(i client.NetworkInterface) bool {
+		return i.Name == name || i.Value == name
+	})
+	if idx < 0 {
+		names := make([]string, 0, len(ifaces))
+		for _, i := range ifaces {
+			names = append(names, i.Name)
+		}
+		return client.NetworkBinding{}, fmt.Errorf("network interface %s is %w (interfaces: %s)", name, errNotAvailable, strings.Join(names, ", "))
+	}
+	binding := client.NetworkBinding{Interface: ifaces[idx].Value, Address: address}
+
+	if address == "" || slices.Contains(wildcardAddresses, address) {
+		return binding, nil
+	}
+
+	addresses, err := cli.NetworkInterfaceAddresses(ctx, binding.Interface)
+	if err != nil {
+		return client.NetworkBinding{}, fmt.Errorf("failed to get interface addresses: %w", err)
+	}
+	if !slices.Contains(addresses, address) {
+		return client.NetworkBinding{}, fmt.Errorf("address %s is %w on interface %s (addresses: %s)", address, errNotAvailable, name, strings.Join(addresses, ", "))
+	}
+	return binding, nil
+}
+
+// checkNetworkBinding fails unless qBittorrent is bound to the interface, and to the address
+// when compareAddress is set. A missing interface or address (e.g. the tunnel is down) fails
+// the check too.
+func checkNetworkBinding(ctx context.Context, cli *client.Client, name string, address string, compareAddress bool) error {
+	current, err := cli.GetNetworkBinding(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get network binding: %w", err)
+	}
+
+	want, err := resolveNetworkBinding(ctx, cli, name, address)
+	if errors.Is(err, errNotAvailable) {
+		return fmt.Errorf("network binding check failed: %w; qBittorrent is bound to %s", err, formatNetworkBinding(current))
+	}
+	if err != nil {
+		return err
+	}
+
+	if !compareAddress {
+		want.Address = current.Address
+	}
+	if current != want {
+		return fmt.Errorf("network binding check failed: qBittorrent is bound to %s, want %s",
+			formatNetworkBinding(current), formatNetworkBinding(want))
+	}
+	return nil
+}
+
+func formatNetworkBinding(b client.NetworkBinding) string {
+	iface, address := b.Interface, b.Address
+	if iface == "" {
+		iface = "any interface"
+	}
+	if address == "" {
+		address = "all addresses"
+	}
+	return fmt.Sprintf("%s (%s)", iface, address)
+}
+
+func init() {
+	networkBindCmd.Flags().String("interface", "", "Network interface to bind to (e.g. tun0)")
+	networkBindCmd.Flags().String("address", "", "Address of the interface to bind to; all addresses if empty")
+	networkBindCmd.Flags().Bool("check", false, "Only verify the binding and fail if qBittorrent is bound elsewhere")
+	_ = networkBindCmd.MarkFlagRequired("interface")
+
+	networkCmd.AddCommand(networkBindCmd)
+	rootCmd.AddCommand(networkCmd)
+}
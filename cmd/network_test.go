@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/qb/qbtest"
+)
+
+func newNetworkTestClient(t *testing.T, ifaces string, binding client.NetworkBinding) *client.Client {
+	t.Helper()
+
+	server := qbtest.NewServer(t)
+	server.Preferences(map[string]any{
+		"current_network_interface": binding.Interface,
+		"current_interface_address": binding.Address,
+	})
+	server.HandleFunc("app/networkInterfaceList", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, ifaces)
+	})
+	server.HandleFunc("app/networkInterfaceAddressList", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `["10.2.0.2","fe80::1"]`)
+	})
+	return server.Client(t)
+}
+
+func TestCheckNetworkBinding(t *testing.T) {
+	const withTunnel = `[{"name":"eth0","value":"eth0"},{"name":"tun0","value":"tun0"}]`
+	const tunnelDown = `[{"name":"eth0","value":"eth0"}]`
+
+	tests := []struct {
+		name           string
+		ifaces         string
+		bound          client.NetworkBinding
+		address        string
+		compareAddress bool
+		wantErr        string
+	}{
+		{name: "bound", ifaces: withTunnel, bound: client.NetworkBinding{Interface: "tun0", Address: "10.2.0.2"}},
+		{name: "address", ifaces: withTunnel, bound: client.NetworkBinding{Interface: "tun0", Address: "10.2.0.2"},
+			address: "10.2.0.2", compareAddress: true},
+		{name: "unbound", ifaces: withTunnel, bound: client.NetworkBinding{},
+			wantErr: "qBittorrent is bound to any interface (all addresses), want tun0"},
+		{name: "elsewhere", ifaces: withTunnel, bound: client.NetworkBinding{Interface: "eth0"},
+			wantErr: "qBittorrent is bound to eth0 (all addresses), want tun0"},
+		{name: "other address", ifaces: withTunnel, bound: client.NetworkBinding{Interface: "tun0"},
+			address: "10.2.0.2", compareAddress: true, wantErr: "want tun0 (10.2.0.2)"},
+		{name: "tunnel down", ifaces: tunnelDown, bound: client.NetworkBinding{Interface: "tun0"},
+			wantErr: "network interface tun0 is not available"},
+		{name: "address gone", ifaces: withTunnel, bound: client.NetworkBinding{Interface: "tun0", Address: "10.2.0.9"},
+			address: "10.2.0.9", compareAddress: true, wantErr: "address 10.2.0.9 is not available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := newNetworkTestClient(t, tt.ifaces, tt.bound)
+			err := checkNetworkBinding(context.Background(), cli, "tun0", tt.address, tt.compareAddress)
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("expected error containing %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			case tt.wantErr != "" && !strings.HasPrefix(err.Error(), "network binding check failed"):
+				t.Errorf("error = %v, want a binding check failure", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"sort"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/units"
+	"github.com/spf13/cobra"
+)
+
+var peersCmd = &cobra.Command{
+	Use:   "peers",
+	Short: "Inspect and ban peers",
+}
+
+var peersListCmd = &cobra.Command{
+	Use:   "list <hash>",
+	Short: "List peers of a torrent",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		peers, err := cli.Peers(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to list peers: %w", err)
+		}
+
+		list := sortedPeers(peers)
+		if format == outputJSON {
+			return printJSON(list)
+		}
+
+		w := newTable()
+		_, _ = fmt.Fprintln(w, "ADDRESS\tCLIENT\tCOUNTRY\tFLAGS\tCONNECTION\tPROGRESS\tDOWN\tUP")
+		for _, p := range list {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				p.Address(),
+				truncate(p.Client, 30),
+				p.CountryCode,
+				p.Flags,
+				p.Connection,
+				units.FormatPercent(p.Progress),
+				units.FormatRate(p.DlSpeed),
+				units.FormatRate(p.UpSpeed),
+			)
+		}
+		return w.Flush()
+	},
+}
+
+func sortedPeers(peers map[string]client.Peer) []client.Peer {
+	list := make([]client.Peer, 0, len(peers))
+	for _, p := range peers {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].DlSpeed+list[i].UpSpeed != list[j].DlSpeed+list[j].UpSpeed {
+			return list[i].DlSpeed+list[i].UpSpeed > list[j].DlSpeed+list[j].UpSpeed
+		}
+		return list[i].Address() < list[j].Address()
+	})
+	return list
+}
+
+var peersAddCmd = newTorrentsActionCmd("add", "Add peers to torrents", nil,
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		peers, _ := cmd.Flags().GetStringSlice("peer")
+		return cli.AddPeers(ctx, hashes, peers)
+	})
+
+type peerBan struct {
+	Address string `json:"address"`
+	Client  string `json:"client,omitempty"`
+	Torrent string `json:"torrent,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+var peersBanCmd = &cobra.Command{
+	Use:   "ban",
+	Short: "Ban peers by IP, CIDR or client name",
+	Long: `Ban peers by IP, CIDR or client name.
+IPs given with --ip are banned directly. For --cidr and --client, the peers of the selected torrents
+(active torrents unless --filter, --category or --tag say otherwise) are scanned and matching ones banned.
+qBittorrent bans whole IP addresses, regardless of the port.`,
+	Example: `  qbcli peers ban --ip 203.0.113.7
+  qbcli peers ban --cidr 198.51.100.0/24 --client '(?i)xunlei|thunder' --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		flags := cmd.Flags()
+		ips, _ := flags.GetStringSlice("ip")
+		cidrs, _ := flags.GetStringSlice("cidr")
+		clientExpr, _ := flags.GetString("client")
+		dryRun, _ := flags.GetBool("dry-run")
+
+		var bans []peerBan
+		for _, ip := range ips {
+			addr, err := netip.ParseAddr(ip)
+			if err != nil {
+				return fmt.Errorf("invalid IP: %s", ip)
+			}
+			bans = append(bans, peerBan{Address: net.JoinHostPort(addr.String(), "0"), Reason: "ip"})
+		}
+
+		var prefixes []netip.Prefix
+		for _, cidr := range cidrs {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				return fmt.Errorf("invalid CIDR: %s", cidr)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+		}
+
+		var clientPattern *regexp.Regexp
+		if clientExpr != "" {
+			var err error
+			if clientPattern, err = regexp.Compile(clientExpr); err != nil {
+				return fmt.Errorf("invalid --client expression: %w", err)
+			}
+		}
+
+		if len(bans) == 0 && len(prefixes) == 0 && clientPattern == nil {
+			return fmt.Errorf("nothing to ban: use --ip, --cidr or --client")
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		var errs []error
+		if len(prefixes) > 0 || clientPattern != nil {
+			if !hasTorrentFilter(cmd) {
+				_ = flags.Set("filter", "active")
+			}
+
+			torrents, err := selectTorrents(ctx, cli, cmd, nil)
+			if err != nil {
+				return err
+			}
+
+			seen := map[string]bool{}
+			for _, t := range torrents {
+				peers, err := cli.Peers(ctx, t.Hash)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", t.Hash, err))
+					continue
+				}
+
+				for _, p := range sortedPeers(peers) {
+					reason := matchPeer(p, prefixes, clientPattern)
+					if reason == "" || seen[p.IP] {
+						continue
+					}
+					seen[p.IP] = true
+					bans = append(bans, peerBan{Address: p.Address(), Client: p.Client, Torrent: t.Name, Reason: reason})
+				}
+			}
+		}
+
+		w := newTable()
+		_, _ = fmt.Fprintln(w, "ADDRESS\tCLIENT\tTORRENT\tREASON")
+		addresses := make([]string, 0, len(bans))
+		for _, ban := range bans {
+			addresses = append(addresses, ban.Address)
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ban.Address, ban.Client, truncate(ban.Torrent, 40), ban.Reason)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if !dryRun && len(addresses) > 0 {
+			if err := cli.BanPeers(ctx, addresses); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to ban peers: %w", errors.Join(errs...))
+		}
+
+		cli.Log.Info("Peers banned successfully", "count", len(addresses), "dryRun", dryRun)
+		return nil
+	},
+}
+
+func matchPeer(p client.Peer, prefixes []netip.Prefix, clientPattern *regexp.Regexp) string {
+	if addr, err := netip.ParseAddr(p.IP); err == nil {
+		for _, prefix := range prefixes {
+			if prefix.Contains(addr.Unmap()) {
+				return "cidr " + prefix.String()
+			}
+		}
+	}
+
+	if clientPattern != nil && (clientPattern.MatchString(p.Client) || clientPattern.MatchString(p.PeerIDClient)) {
+		return "client"
+	}
+	return ""
+}
+
+func init() {
+	addOutputFlag(peersListCmd)
+
+	peersAddCmd.Flags().StringSlice("peer", nil, "Peers to add as host:port")
+	_ = peersAddCmd.MarkFlagRequired("peer")
+
+	peersBanCmd.Flags().StringSlice("ip", nil, "IP addresses to ban")
+	peersBanCmd.Flags().StringSlice("cidr", nil, "Ban connected peers within these networks (e.g. 198.51.100.0/24)")
+	peersBanCmd.Flags().String("client", "", "Ban connected peers whose client name matches this regular expression")
+	peersBanCmd.Flags().Bool("dry-run", false, "Only report what would be banned")
+	addTorrentFilterFlags(peersBanCmd)
+
+	peersCmd.AddCommand(peersListCmd, peersAddCmd, peersBanCmd)
+	rootCmd.AddCommand(peersCmd)
+}
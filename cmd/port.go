@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gstos/qbcli/internal/natpmp"
+	"github.com/gstos/qbcli/internal/portsync"
+	"github.com/spf13/cobra"
+)
+
+var portCmd = &cobra.Command{
+	Use:   "port",
+	Short: "Keep the listening port in sync with a forwarded port",
+}
+
+var portSyncCmd = &cobra.Command{
+	Use:   "sync (--from-file <path> | --from-gluetun <url>)",
+	Short: "Keep the listening port in sync with a forwarded port source",
+	Long: `Run until interrupted, keeping qBittorrent's listening port equal to the forwarded port.
+With --from-file, the file (e.g. gluetun's /tmp/gluetun/forwarded_port) is watched and the port
+is applied as soon as it changes. With --from-gluetun, gluetun's HTTP control server is queried.
+In both cases the port is reconciled every --interval, which restores it if qBittorrent
+restarted or the setting drifted.
+The gluetun API key and password may also be given through QBCLI_GLUETUN_API_KEY and
+QBCLI_GLUETUN_PASSWORD.`,
+	Example: `  qbcli port sync --from-file /tmp/gluetun/forwarded_port --interval 30s
+  qbcli port sync --from-gluetun http://gluetun:8000 --gluetun-api-key "$KEY"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rootCtx, cancel := rootEnv.Context()
+		defer cancel()
+
+		ctx, stop := signal.NotifyContext(rootCtx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		flags := cmd.Flags()
+		fromFile, _ := flags.GetString("from-file")
+		fromGluetun, _ := flags.GetString("from-gluetun")
+		interval, _ := flags.GetDuration("interval")
+
+		if interval <= 0 {
+			return fmt.Errorf("invalid interval: %s", interval)
+		}
+
+		var source portsync.Source
+		switch {
+		case fromFile != "" && fromGluetun != "":
+			return fmt.Errorf("use either --from-file or --from-gluetun")
+		case fromFile != "":
+			source = portsync.NewFileSource(fromFile)
+		case fromGluetun != "":
+			source = gluetunSourceFromFlags(cmd, fromGluetun)
+		default:
+			return fmt.Errorf("no port source: use --from-file or --from-gluetun")
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		cli.Log.Info("Syncing listening port", "source", source.Name(), "interval", interval)
+
+		syncer := portsync.New(cli, source,
+			portsync.WithInterval(interval),
+			portsync.WithListeningPortOptions(listeningPortOptionsFromFlags(cmd)),
+		)
+		if err := syncer.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			return fmt.Errorf("failed to sync listening port: %w", err)
+		}
+		return nil
+	},
+}
+
+var portNATPMPCmd = &cobra.Command{
+	Use:   "natpmp --gateway <address>",
+	Short: "Forward a port with NAT-PMP and keep the listening port in sync",
+	Long: `Run until interrupted, requesting TCP and UDP port mappings from a NAT-PMP gateway
+(e.g. ProtonVPN's 10.2.0.1), renewing them before their lease expires, and setting qBittorrent's
+listening port to the public port. The port is also reconciled every --interval, which restores
+it if qBittorrent restarted. Mappings are deleted on exit.`,
+	Example: `  qbcli port natpmp --gateway 10.2.0.1`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rootCtx, cancel := rootEnv.Context()
+		defer cancel()
+
+		ctx, stop := signal.NotifyContext(rootCtx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		flags := cmd.Flags()
+		gateway, _ := flags.GetString("gateway")
+		internalPort, _ := flags.GetInt("internal-port")
+		lifetime, _ := flags.GetDuration("lifetime")
+		interval, _ := flags.GetDuration("interval")
+
+		if interval <= 0 {
+			return fmt.Errorf("invalid interval: %s", interval)
+		}
+		if lifetime < 2*time.Second {
+			return fmt.Errorf("invalid lifetime: %s", lifetime)
+		}
+		if internalPort <= 0 || internalPort > 65535 {
+			return fmt.Errorf("invalid internal port: %d", internalPort)
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		source := portsync.NewNATPMPSource(natpmp.New(gateway), internalPort, lifetime, cli.Log)
+		cli.Log.Info("Syncing listening port", "source", source.Name(), "interval", interval)
+
+		syncer := portsync.New(cli, source,
+			portsync.WithInterval(interval),
+			portsync.WithListeningPortOptions(listeningPortOptionsFromFlags(cmd)),
+		)
+		if err := syncer.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			return fmt.Errorf("failed to sync listening port: %w", err)
+		}
+		return nil
+	},
+}
+
+func gluetunSourceFromFlags(cmd *cobra.Command, baseURL string) *portsync.GluetunSource {
+	flags := cmd.Flags()
+
+	source := portsync.NewGluetunSource(baseURL)
+	source.APIKey, _ = flags.GetString("gluetun-api-key")
+	source.Username, _ = flags.GetString("gluetun-username")
+	source.Password, _ = flags.GetString("gluetun-password")
+
+	if !flags.Changed("gluetun-api-key") {
+		source.APIKey = os.Getenv("QBCLI_GLUETUN_API_KEY")
+	}
+	if !flags.Changed("gluetun-password") {
+		source.Password = os.Getenv("QBCLI_GLUETUN_PASSWORD")
+	}
+	return source
+}
+
+func init() {
+	portSyncCmd.Flags().String("from-file", "", "File holding the forwarded port (e.g. /tmp/gluetun/forwarded_port)")
+	portSyncCmd.Flags().String("from-gluetun", "", "Base URL of the gluetun control server (e.g. http://gluetun:8000)")
+	portSyncCmd.Flags().String("gluetun-api-key", "", "API key for the gluetun control server (overrides QBCLI_GLUETUN_API_KEY)")
+	portSyncCmd.Flags().String("gluetun-username", "", "Username for the gluetun control server basic auth")
+	portSyncCmd.Flags().String("gluetun-password", "", "Password for the gluetun control server basic auth (overrides QBCLI_GLUETUN_PASSWORD)")
+	portSyncCmd.Flags().Duration("interval", time.Minute, "Interval between periodic reconciles")
+
+	portNATPMPCmd.Flags().String("gateway", "", "NAT-PMP gateway address, optionally with port (e.g. 10.2.0.1)")
+	portNATPMPCmd.Flags().Int("internal-port", 1, "Internal port to map (ProtonVPN forwards the public port whatever this value)")
+	portNATPMPCmd.Flags().Duration("lifetime", time.Minute, "Requested mapping lifetime; mappings are renewed at half the granted lifetime")
+	portNATPMPCmd.Flags().Duration("interval", time.Minute, "Interval between periodic reconciles")
+	_ = portNATPMPCmd.MarkFlagRequired("gateway")
+
+	addListeningPortFlags(portSyncCmd)
+	addListeningPortFlags(portNATPMPCmd)
+
+	portCmd.AddCommand(portSyncCmd, portNATPMPCmd)
+	rootCmd.AddCommand(portCmd)
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/gstos/qbcli/internal/backup"
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <dir|file.tar|file.tar.gz>",
+	Short: "Add back torrents from a backup",
+	Long: `Add back the torrents of a backup created by 'qbcli backup', with their name, content layout,
+save path, category, tags, limits and stopped state. Torrents already present in qBittorrent are skipped.
+The added-on time is kept in the manifest only, since qBittorrent cannot set it. Renamed files and
+folders inside a torrent are not restored, so such torrents re-check against their original paths.`,
+	Example: `  qbcli restore /backups/qbittorrent-2025-01-01.tar.gz --skip-checking`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		flags := cmd.Flags()
+		dryRun, _ := flags.GetBool("dry-run")
+		skipChecking, _ := flags.GetBool("skip-checking")
+		forceStopped, _ := flags.GetBool("stopped")
+
+		r, err := backup.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open backup: %w", err)
+		}
+		defer func() { _ = r.Close() }()
+
+		manifest, err := backup.ReadManifest(r)
+		if err != nil {
+			return fmt.Errorf("failed to open backup: %w", err)
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		existing, err := cli.ListTorrents(ctx, client.ListTorrentsOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list torrents: %w", err)
+		}
+		present := map[string]bool{}
+		for _, t := range existing {
+			present[t.Hash] = true
+		}
+
+		var errs []error
+		restored := 0
+		for _, entry := range manifest.Torrents {
+			if present[entry.Hash] {
+				cli.Log.Debug("torrent already present, skipping", "name", entry.Name, "hash", entry.Hash)
+				continue
+			}
+
+			opts := restoreOptions(entry, skipChecking, forceStopped)
+			if entry.File != "" {
+				content, err := r.ReadFile(entry.File)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", entry.Name, err))
+					continue
+				}
+				opts.Files = []client.TorrentFile{{Name: path.Base(entry.File), Content: content}}
+			} else if entry.MagnetURI != "" {
+				opts.URLs = []string{entry.MagnetURI}
+			} else {
+				errs = append(errs, fmt.Errorf("%s: no torrent file nor magnet link in backup", entry.Name))
+				continue
+			}
+
+			fmt.Printf("restore\t%s\t%s\n", entry.Hash, entry.Name)
+			if dryRun {
+				continue
+			}
+
+			if err := cli.AddTorrents(ctx, opts); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", entry.Name, err))
+				continue
+			}
+			restored++
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to restore torrents: %w", errors.Join(errs...))
+		}
+
+		cli.Log.Info("Torrents restored successfully", "restored", restored, "inBackup", len(manifest.Torrents), "dryRun", dryRun)
+		return nil
+	},
+}
+
+// restoreOptions maps a backup entry to torrents/add fields. Zero share limits are not restored:
+// older qBittorrent releases do not report some of them, and a zero limit would stop seeding at once.
+func restoreOptions(entry backup.Entry, skipChecking bool, forceStopped bool) client.AddTorrentsOptions {
+	stopped := entry.Stopped || forceStopped
+	autoTMM := entry.AutoTMM
+
+	opts := client.AddTorrentsOptions{
+		SavePath:      entry.SavePath,
+		DownloadPath:  entry.DownloadPath,
+		Category:      entry.Category,
+		Tags:          entry.Tags,
+		ContentLayout: entry.ContentLayout,
+		Stopped:       &stopped,
+		SkipChecking:  skipChecking,
+		AutoTMM:       &autoTMM,
+		UpLimit:       entry.UpLimit,
+		DlLimit:       entry.DlLimit,
+	}
+
+	// Magnets without metadata are named after their hash, which would stick as a custom name
+	if entry.Name != entry.Hash {
+		opts.Rename = entry.Name
+	}
+
+	if entry.RatioLimit != 0 {
+		ratioLimit := entry.RatioLimit
+		opts.RatioLimit = &ratioLimit
+	}
+	if entry.SeedingTimeLimit != 0 {
+		seedingTimeLimit := int(entry.SeedingTimeLimit)
+		opts.SeedingTimeLimit = &seedingTimeLimit
+	}
+	if entry.InactiveSeedingTimeLimit != 0 {
+		inactiveSeedingTimeLimit := int(entry.InactiveSeedingTimeLimit)
+		opts.InactiveSeedingTimeLimit = &inactiveSeedingTimeLimit
+	}
+	return opts
+}
+
+func init() {
+	restoreCmd.Flags().Bool("dry-run", false, "Only list the torrents that would be added")
+	restoreCmd.Flags().Bool("skip-checking", false, "Skip hash checking of existing data")
+	restoreCmd.Flags().Bool("stopped", false, "Add all torrents in the stopped (paused) state")
+	rootCmd.AddCommand(restoreCmd)
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/gstos/qbcli/internal/backup"
+)
+
+func TestRestoreOptionsKeepNameAndLayout(t *testing.T) {
+	opts := restoreOptions(backup.Entry{Hash: "aaa", Name: "My Show S01", ContentLayout: "NoSubfolder"}, false, false)
+	if opts.Rename != "My Show S01" || opts.ContentLayout != "NoSubfolder" {
+		t.Errorf("rename = %q, content layout = %q; want the backed up ones", opts.Rename, opts.ContentLayout)
+	}
+
+	opts = restoreOptions(backup.Entry{Hash: "aaa", Name: "aaa"}, false, false)
+	if opts.Rename != "" || opts.ContentLayout != "" {
+		t.Errorf("magnet without metadata: rename = %q, content layout = %q; want qBittorrent's defaults",
+			opts.Rename, opts.ContentLayout)
+	}
+}
@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/spf13/cobra"
+)
+
+var rssCmd = &cobra.Command{
+	Use:   "rss",
+	Short: "Manage RSS feeds",
+	Long: `Manage RSS feeds.
+Item paths use a backslash to separate folders, as in qBittorrent (e.g. 'TV\Show feed').`,
+}
+
+var rssListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the RSS folders and feeds tree",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		root, err := cli.RSSItems(ctx, format == outputTable)
+		if err != nil {
+			return fmt.Errorf("failed to list RSS items: %w", err)
+		}
+
+		if format == outputJSON {
+			return printJSON(root.Children)
+		}
+
+		printRSSTree(root.Children, 0)
+		return nil
+	},
+}
+
+func printRSSTree(items []client.RSSItem, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, item := range items {
+		name := path.Base(strings.ReplaceAll(item.Path, client.RSSPathSeparator, "/"))
+		if item.IsFolder() {
+			fmt.Printf("%s%s/\n", indent, name)
+			printRSSTree(item.Children, depth+1)
+			continue
+		}
+
+		status := fmt.Sprintf("%d unread", item.Feed.Unread())
+		if item.Feed.HasError {
+			status += ", error"
+		}
+		if item.Feed.IsLoading {
+			status += ", loading"
+		}
+		fmt.Printf("%s%s  <%s>  [%s]\n", indent, name, item.Feed.URL, status)
+	}
+}
+
+var rssAddFeedCmd = &cobra.Command{
+	Use:   "add-feed <url> [path]",
+	Short: "Subscribe to a feed",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		itemPath := ""
+		if len(args) == 2 {
+			itemPath = args[1]
+		}
+
+		return addRSSFeed(ctx, cli, args[0], itemPath)
+	},
+}
+
+// addRSSFeed subscribes to feedURL. The URL is left out of the log, since private
+// feeds carry the passkey in it.
+func addRSSFeed(ctx context.Context, cli *client.Client, feedURL string, itemPath string) error {
+	if err := cli.AddRSSFeed(ctx, feedURL, itemPath); err != nil {
+		return fmt.Errorf("failed to add feed: %w", err)
+	}
+
+	cli.Log.Info("Feed added successfully", "path", itemPath)
+	return nil
+}
+
+var rssAddFolderCmd = &cobra.Command{
+	Use:   "add-folder <path>",
+	Short: "Create a folder",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.AddRSSFolder(ctx, args[0]); err != nil {
+			return fmt.Errorf("failed to add folder: %w", err)
+		}
+
+		cli.Log.Info("Folder added successfully", "path", args[0])
+		return nil
+	},
+}
+
+var rssRemoveCmd = &cobra.Command{
+	Use:   "remove <path>...",
+	Short: "Remove feeds or folders",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		for _, itemPath := range args {
+			if err := cli.RemoveRSSItem(ctx, itemPath); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", itemPath, err)
+			}
+		}
+
+		cli.Log.Info("Items removed successfully", "paths", args)
+		return nil
+	},
+}
+
+var rssMoveCmd = &cobra.Command{
+	Use:   "move <path> <dest-path>",
+	Short: "Move or rename a feed or folder",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.MoveRSSItem(ctx, args[0], args[1]); err != nil {
+			return fmt.Errorf("failed to move item: %w", err)
+		}
+
+		cli.Log.Info("Item moved successfully", "path", args[0], "destPath", args[1])
+		return nil
+	},
+}
+
+var rssRefreshCmd = &cobra.Command{
+	Use:   "refresh [path...]",
+	Short: "Refresh feeds or folders (all feeds when no path is given)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if len(args) == 0 {
+			// The root folder has an empty path
+			args = []string{""}
+		}
+
+		for _, itemPath := range args {
+			if err := cli.RefreshRSSItem(ctx, itemPath); err != nil {
+				return fmt.Errorf("failed to refresh %s: %w", itemPath, err)
+			}
+		}
+
+		cli.Log.Info("Items refreshed successfully", "paths", args)
+		return nil
+	},
+}
+
+type rssArticleRow struct {
+	Feed string `json:"feed"`
+	client.RSSArticle
+}
+
+var rssArticlesCmd = &cobra.Command{
+	Use:   "articles [path]",
+	Short: "Show articles of all feeds or of a feed or folder",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		all, _ := cmd.Flags().GetBool("all")
+		markRead, _ := cmd.Flags().GetBool("mark-read")
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		root, err := cli.RSSItems(ctx, true)
+		if err != nil {
+			return fmt.Errorf("failed to list RSS items: %w", err)
+		}
+
+		prefix := ""
+		if len(args) == 1 {
+			prefix = args[0]
+		}
+
+		var rows []rssArticleRow
+		var marked []string
+		for feedPath, feed := range root.Feeds() {
+			if prefix != "" && feedPath != prefix && !strings.HasPrefix(feedPath, prefix+client.RSSPathSeparator) {
+				continue
+			}
+			for _, article := range feed.Articles {
+				if article.IsRead && !all {
+					continue
+				}
+				rows = append(rows, rssArticleRow{Feed: feedPath, RSSArticle: article})
+			}
+			if feed.Unread() > 0 {
+				marked = append(marked, feedPath)
+			}
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			if rows[i].Feed != rows[j].Feed {
+				return rows[i].Feed < rows[j].Feed
+			}
+			return rows[i].Title < rows[j].Title
+		})
+
+		if format == outputJSON {
+			err = printJSON(rows)
+		} else {
+			w := newTable()
+			_, _ = fmt.Fprintln(w, "FEED\tDATE\tTITLE")
+			for _, row := range rows {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", truncate(row.Feed, 30), row.Date, row.Title)
+			}
+			err = w.Flush()
+		}
+		if err != nil {
+			return err
+		}
+
+		if markRead {
+			for _, feedPath := range marked {
+				if err := cli.MarkRSSAsRead(ctx, feedPath, ""); err != nil {
+					return fmt.Errorf("failed to mark %s as read: %w", feedPath, err)
+				}
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	addOutputFlag(rssListCmd)
+	addOutputFlag(rssArticlesCmd)
+	rssArticlesCmd.Flags().Bool("all", false, "Include articles already read")
+	rssArticlesCmd.Flags().Bool("mark-read", false, "Mark the listed feeds as read afterwards")
+
+	rssCmd.AddCommand(rssListCmd, rssAddFeedCmd, rssAddFolderCmd, rssRemoveCmd, rssMoveCmd, rssRefreshCmd, rssArticlesCmd)
+	rootCmd.AddCommand(rssCmd)
+}
@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var rssRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Manage RSS auto-downloading rules",
+}
+
+var rssRulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List rules (JSON output can be used as a rules file)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		rules, err := cli.RSSRules(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list rules: %w", err)
+		}
+
+		if format == outputJSON {
+			return printJSON(rules)
+		}
+
+		w := newTable()
+		_, _ = fmt.Fprintln(w, "NAME\tENABLED\tMUST CONTAIN\tMUST NOT CONTAIN\tFEEDS\tLAST MATCH")
+		for _, name := range sortedKeys(rules) {
+			rule := rules[name]
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+				name,
+				ruleField(rule, "enabled"),
+				ruleField(rule, "mustContain"),
+				ruleField(rule, "mustNotContain"),
+				countOf(rule["affectedFeeds"]),
+				ruleField(rule, "lastMatch"),
+			)
+		}
+		return w.Flush()
+	},
+}
+
+func ruleField(rule map[string]any, key string) string {
+	if v, ok := rule[key]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+func countOf(v any) int {
+	if list, ok := v.([]any); ok {
+		return len(list)
+	}
+	return 0
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var rssRulesApplyCmd = &cobra.Command{
+	Use:   "apply -f <rules.yaml>",
+	Short: "Reconcile the server rules with a rules file",
+	Long: `Reconcile the server rules with a YAML or JSON rules file mapping rule names to definitions,
+in the same shape as 'qbcli rss rules list -o json'.
+Rules missing on the server are created and rules whose fields differ are updated. Rules absent
+from the file are only deleted with --prune. Only the fields present in the file are
+compared; other fields, such as lastMatch, are preserved on update.
+A file without rules is refused, so that an empty or truncated file cannot wipe the server rules.
+Use 'qbcli rss rules rename' to rename a rule: renamed in the file only, it is created anew
+(and, with --prune, the old one deleted along with its history).`,
+	Example: `  qbcli rss rules apply -f rules.yaml --dry-run
+  qbcli rss rules apply -f rules.yaml --prune`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		filePath, _ := cmd.Flags().GetString("file")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		prune, _ := cmd.Flags().GetBool("prune")
+
+		desired, err := readRulesFile(filePath)
+		if err != nil {
+			return err
+		}
+		if len(desired) == 0 {
+			return fmt.Errorf("rules file %s defines no rules", filePath)
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		current, err := cli.RSSRules(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list rules: %w", err)
+		}
+
+		var errs []error
+		apply := func(action string, name string, do func() error) {
+			fmt.Printf("%s\t%s\n", action, name)
+			if dryRun {
+				return
+			}
+			if err := do(); err != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %w", action, name, err))
+			}
+		}
+
+		for _, name := range sortedKeys(desired) {
+			def := desired[name]
+			existing, found := current[name]
+			switch {
+			case !found:
+				apply("create", name, func() error { return cli.SetRSSRule(ctx, name, def) })
+			case !ruleMatches(existing, def):
+				merged := mergeRule(existing, def)
+				apply("update", name, func() error { return cli.SetRSSRule(ctx, name, merged) })
+			}
+		}
+
+		if prune {
+			for _, name := range sortedKeys(current) {
+				if _, found := desired[name]; !found {
+					apply("delete", name, func() error { return cli.RemoveRSSRule(ctx, name) })
+				}
+			}
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to apply rules: %w", errors.Join(errs...))
+		}
+
+		cli.Log.Info("Rules applied successfully", "rules", len(desired), "dryRun", dryRun)
+		return nil
+	},
+}
+
+func readRulesFile(filePath string) (map[string]map[string]any, error) {
+	var reader io.Reader
+	if filePath == "-" {
+		reader = os.Stdin
+	} else {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open rules file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		reader = file
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	rules := map[string]map[string]any{}
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		err = json.Unmarshal(data, &rules)
+	} else {
+		err = yaml.Unmarshal(data, &rules)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+
+	// Normalize through JSON so values compare equal to the ones decoded from the server
+	normalized := map[string]map[string]any{}
+	if data, err = json.Marshal(rules); err != nil {
+		return nil, fmt.Errorf("failed to normalize rules: %w", err)
+	}
+	if err = json.Unmarshal(data, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to normalize rules: %w", err)
+	}
+	return normalized, nil
+}
+
+// ruleMatches reports whether every field of the desired definition has the same value on the server.
+// Nested objects (such as torrentParams) are compared field by field, so they may be partial too.
+func ruleMatches(current map[string]any, desired map[string]any) bool {
+	for k, v := range desired {
+		want, wantObject := v.(map[string]any)
+		got, gotObject := current[k].(map[string]any)
+		if wantObject && gotObject {
+			if !ruleMatches(got, want) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(current[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeRule returns the server's definition overridden by the desired fields. Nested objects are
+// merged field by field, so that the fields missing from the desired definition are kept.
+func mergeRule(current map[string]any, desired map[string]any) map[string]any {
+	merged := make(map[string]any, len(current))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range desired {
+		want, wantObject := v.(map[string]any)
+		got, gotObject := merged[k].(map[string]any)
+		if wantObject && gotObject {
+			merged[k] = mergeRule(got, want)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+var rssRulesTestCmd = &cobra.Command{
+	Use:   "test <rule>",
+	Short: "Show which current articles match a rule",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		articles, err := cli.RSSMatchingArticles(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to test rule: %w", err)
+		}
+
+		if format == outputJSON {
+			return printJSON(articles)
+		}
+
+		w := newTable()
+		_, _ = fmt.Fprintln(w, "FEED\tARTICLE")
+		for _, feed := range sortedKeys(articles) {
+			for _, title := range articles[feed] {
+				_, _ = fmt.Fprintf(w, "%s\t%s\n", feed, title)
+			}
+		}
+		return w.Flush()
+	},
+}
+
+var rssRulesRenameCmd = &cobra.Command{
+	Use:   "rename <rule> <new-name>",
+	Short: "Rename a rule",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.RenameRSSRule(ctx, args[0], args[1]); err != nil {
+			return fmt.Errorf("failed to rename rule: %w", err)
+		}
+
+		cli.Log.Info("Rule renamed successfully", "rule", args[0], "newName", args[1])
+		return nil
+	},
+}
+
+var rssRulesRemoveCmd = &cobra.Command{
+	Use:   "remove <rule>...",
+	Short: "Remove rules",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		for _, name := range args {
+			if err := cli.RemoveRSSRule(ctx, name); err != nil {
+				return fmt.Errorf("failed to remove rule %s: %w", name, err)
+			}
+		}
+
+		cli.Log.Info("Rules removed successfully", "rules", args)
+		return nil
+	},
+}
+
+func init() {
+	addOutputFlag(rssRulesListCmd)
+	addOutputFlag(rssRulesTestCmd)
+
+	rssRulesApplyCmd.Flags().StringP("file", "f", "", "Path to YAML or JSON rules file or '-' for stdin (YAML)")
+	rssRulesApplyCmd.Flags().Bool("dry-run", false, "Only report what would change")
+	rssRulesApplyCmd.Flags().Bool("prune", false, "Delete server rules missing from the file")
+	_ = rssRulesApplyCmd.MarkFlagRequired("file")
+
+	rssRulesCmd.AddCommand(rssRulesListCmd, rssRulesApplyCmd, rssRulesTestCmd, rssRulesRenameCmd, rssRulesRemoveCmd)
+	rssCmd.AddCommand(rssRulesCmd)
+}
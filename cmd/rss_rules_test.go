@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadRulesFile(t *testing.T) {
+	want := map[string]map[string]any{
+		"My Show": {
+			"enabled":       true,
+			"mustContain":   "My Show 1080p",
+			"affectedFeeds": []any{"https://example.org/feed.xml"},
+			"torrentParams": map[string]any{"category": "tv", "ratio_limit": float64(2)},
+		},
+	}
+
+	tests := map[string]string{
+		"rules.yaml": `My Show:
+  enabled: true
+  mustContain: "My Show 1080p"
+  affectedFeeds: ["https://example.org/feed.xml"]
+  torrentParams:
+    category: tv
+    ratio_limit: 2
+`,
+		"rules.json": `{"My Show": {"enabled": true, "mustContain": "My Show 1080p",
+  "affectedFeeds": ["https://example.org/feed.xml"],
+  "torrentParams": {"category": "tv", "ratio_limit": 2}}}`,
+	}
+
+	dir := t.TempDir()
+	for name, content := range tests {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := readRulesFile(path)
+		if err != nil {
+			t.Errorf("readRulesFile(%s) unexpected error: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("readRulesFile(%s) = %#v, want %#v", name, got, want)
+		}
+	}
+}
+
+func TestReadRulesFileInvalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"list.yaml":  "- a\n- b\n",
+		"bad.json":   "{",
+		"types.yaml": "My Show: true\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readRulesFile(path); err == nil {
+			t.Errorf("readRulesFile(%s) expected error", name)
+		}
+	}
+
+	if _, err := readRulesFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("readRulesFile(missing.yaml) expected error")
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	current := map[string]any{
+		"enabled":       true,
+		"mustContain":   "1080p",
+		"lastMatch":     "12 Oct 2025",
+		"affectedFeeds": []any{"a", "b"},
+		"torrentParams": map[string]any{"category": "tv", "save_path": "/data/tv", "tags": []any{"rss"}},
+	}
+
+	tests := []struct {
+		name    string
+		desired map[string]any
+		want    bool
+	}{
+		{"empty", map[string]any{}, true},
+		{"subset", map[string]any{"enabled": true, "mustContain": "1080p"}, true},
+		{"nested", map[string]any{"torrentParams": map[string]any{"category": "tv"}}, true},
+		{"different value", map[string]any{"enabled": false}, false},
+		{"missing on server", map[string]any{"mustNotContain": "cam"}, false},
+		{"list order", map[string]any{"affectedFeeds": []any{"b", "a"}}, false},
+		{"nested difference", map[string]any{"torrentParams": map[string]any{"category": "movies"}}, false},
+		{"nested missing on server", map[string]any{"torrentParams": map[string]any{"stopped": true}}, false},
+		{"nested over a value", map[string]any{"lastMatch": map[string]any{"date": "12 Oct 2025"}}, false},
+	}
+
+	for _, tt := range tests {
+		if got := ruleMatches(current, tt.desired); got != tt.want {
+			t.Errorf("%s: ruleMatches() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMergeRule(t *testing.T) {
+	current := map[string]any{
+		"enabled":       false,
+		"mustContain":   "1080p",
+		"torrentParams": map[string]any{"category": "tv", "save_path": "/data/tv", "tags": []any{"rss"}},
+	}
+	desired := map[string]any{
+		"enabled":       true,
+		"torrentParams": map[string]any{"category": "anime", "stopped": true},
+	}
+
+	want := map[string]any{
+		"enabled":     true,
+		"mustContain": "1080p",
+		"torrentParams": map[string]any{
+			"category":  "anime",
+			"save_path": "/data/tv",
+			"tags":      []any{"rss"},
+			"stopped":   true,
+		},
+	}
+	merged := mergeRule(current, desired)
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("mergeRule() = %v, want %v", merged, want)
+	}
+	if !ruleMatches(merged, desired) {
+		t.Error("merged rule does not match the desired one")
+	}
+	if got := current["torrentParams"].(map[string]any)["category"]; got != "tv" {
+		t.Errorf("mergeRule() modified the current rule: category = %v", got)
+	}
+}
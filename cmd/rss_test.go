@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/qb/qbtest"
+)
+
+func TestAddRSSFeedDoesNotLogPasskey(t *testing.T) {
+	server := qbtest.NewServer(t)
+	server.HandleFunc("rss/addFeed", func(w http.ResponseWriter, r *http.Request) {})
+
+	var logs bytes.Buffer
+	cli := server.Client(t, client.WithCustomLogger(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	if err := addRSSFeed(context.Background(), cli, "https://tracker.example/rss?passkey=s3cr3t", "Private"); err != nil {
+		t.Fatalf("addRSSFeed() = %v", err)
+	}
+
+	if strings.Contains(logs.String(), "s3cr3t") {
+		t.Errorf("passkey logged:\n%s", logs.String())
+	}
+	if !strings.Contains(logs.String(), "path=Private") {
+		t.Errorf("log is missing the feed path:\n%s", logs.String())
+	}
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/units"
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search torrents with the qBittorrent search engine",
+	Long: `Start a search job, wait until it finishes (or --wait expires) and print the results
+ranked by seeders. Use --add with a rank to also add that result of this run to qBittorrent;
+the listing is printed first and the added result is named after it.
+Ranks change between runs, as plugins answer at different speeds: to add a result of an
+earlier listing, pass its fileUrl (shown with -o json) to 'qbcli torrents add'.
+The search job is deleted afterwards.`,
+	Example: `  qbcli search "debian netinst" --category software --plugins all
+  qbcli search "debian netinst" --add 1 --add-category linux`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rootCtx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		flags := cmd.Flags()
+		wait, _ := flags.GetDuration("wait")
+		interval, _ := flags.GetDuration("interval")
+		limit, _ := flags.GetInt("limit")
+		add, _ := flags.GetInt("add")
+
+		if interval <= 0 {
+			return fmt.Errorf("invalid interval: %s", interval)
+		}
+		if add < 0 {
+			return fmt.Errorf("invalid rank: %d", add)
+		}
+
+		opts := client.SearchOptions{Pattern: strings.Join(args, " ")}
+		opts.Category, _ = flags.GetString("category")
+		opts.Plugins, _ = flags.GetStringSlice("plugins")
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		id, err := cli.StartSearch(rootCtx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to start search: %w", err)
+		}
+		defer func() {
+			if err := cli.DeleteSearch(rootCtx, id); err != nil {
+				cli.Log.Warn("failed to delete search job", "id", id, "error", err)
+			}
+		}()
+
+		if err := waitForSearch(rootCtx, cli, id, wait, interval); err != nil {
+			return err
+		}
+
+		results, err := cli.SearchResults(rootCtx, id, 0, 0)
+		if err != nil {
+			return fmt.Errorf("failed to get search results: %w", err)
+		}
+
+		ranked := rankSearchResults(results.Results)
+		if add > len(ranked) {
+			return fmt.Errorf("invalid rank %d: only %d results", add, len(ranked))
+		}
+
+		// The listing always covers the added result, so that what is added can be checked
+		shown := ranked
+		if limit > 0 && len(shown) > max(limit, add) {
+			shown = shown[:max(limit, add)]
+		}
+		if err := printSearchResults(format, shown); err != nil {
+			return err
+		}
+
+		if add > 0 {
+			result := ranked[add-1]
+			if format != outputJSON {
+				fmt.Printf("add\t%d\t%s\t%s\n", add, result.FileName, searchResultLink(result))
+			}
+			return addSearchResult(rootCtx, cli, cmd, result)
+		}
+		return nil
+	},
+}
+
+func printSearchResults(format string, ranked []client.SearchResult) error {
+	if format == outputJSON {
+		return printJSON(ranked)
+	}
+
+	w := newTable()
+	_, _ = fmt.Fprintln(w, "RANK\tNAME\tSIZE\tSEEDS\tLEECH\tENGINE")
+	for i, r := range ranked {
+		engine := r.EngineName
+		if engine == "" {
+			engine = r.SiteURL
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%s\n",
+			i+1,
+			truncate(r.FileName, 70),
+			units.FormatBytes(r.FileSize),
+			r.NbSeeders,
+			r.NbLeechers,
+			engine,
+		)
+	}
+	return w.Flush()
+}
+
+// searchResultLink returns the description page of a result. The file URL is not shown,
+// as private trackers put the passkey in it.
+func searchResultLink(result client.SearchResult) string {
+	if result.DescrLink != "" {
+		return result.DescrLink
+	}
+	return result.SiteURL
+}
+
+// waitForSearch polls the job until it stops. When wait expires or the command is interrupted,
+// the job is stopped and the results found so far are kept.
+func waitForSearch(rootCtx context.Context, cli *client.Client, id int, wait time.Duration, interval time.Duration) error {
+	ctx, stop := signal.NotifyContext(rootCtx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if wait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, wait)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job, err := cli.SearchStatus(ctx, id)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			return fmt.Errorf("failed to get search status: %w", err)
+		case err == nil && !job.IsRunning():
+			return nil
+		case err == nil:
+			cli.Log.Debug("search running", "id", id, "results", job.Total)
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				cli.Log.Warn("search did not finish in time, showing partial results", "id", id, "wait", wait)
+			}
+			if err := cli.StopSearch(rootCtx, id); err != nil {
+				return fmt.Errorf("failed to stop search: %w", err)
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// rankSearchResults orders results by seeders, then leechers, best first.
+func rankSearchResults(results []client.SearchResult) []client.SearchResult {
+	ranked := append([]client.SearchResult(nil), results...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].NbSeeders != ranked[j].NbSeeders {
+			return ranked[i].NbSeeders > ranked[j].NbSeeders
+		}
+		return ranked[i].NbLeechers > ranked[j].NbLeechers
+	})
+	return ranked
+}
+
+func addSearchResult(ctx context.Context, cli *client.Client, cmd *cobra.Command, result client.SearchResult) error {
+	flags := cmd.Flags()
+
+	opts := client.AddTorrentsOptions{URLs: []string{result.FileURL}}
+	opts.SavePath, _ = flags.GetString("save-path")
+	opts.Category, _ = flags.GetString("add-category")
+	opts.Tags, _ = flags.GetStringSlice("tags")
+	if flags.Changed("stopped") {
+		stopped, _ := flags.GetBool("stopped")
+		opts.Stopped = &stopped
+	}
+
+	if err := cli.AddTorrents(ctx, opts); err != nil {
+		return fmt.Errorf("failed to add search result: %w", err)
+	}
+
+	cli.Log.Info("Search result added successfully", "name", result.FileName, "link", searchResultLink(result))
+	return nil
+}
+
+func init() {
+	flags := searchCmd.Flags()
+	flags.String("category", "all", "Search category (e.g. all, movies, tv, music, games, anime, software, books)")
+	flags.StringSlice("plugins", nil, "Search plugins to use: all, enabled or plugin names (default enabled)")
+	flags.Duration("wait", time.Minute, "Maximum time to wait for the search to finish (0 to wait indefinitely)")
+	flags.Duration("interval", time.Second, "Status polling interval")
+	flags.Int("limit", 20, "Maximum number of results to show (0 for all)")
+	flags.Int("add", 0, "Add the result with this rank after printing the results")
+	flags.String("save-path", "", "Download folder for the added torrent")
+	flags.String("add-category", "", "Category for the added torrent")
+	flags.StringSlice("tags", nil, "Tags for the added torrent")
+	flags.Bool("stopped", false, "Add the torrent in the stopped (paused) state")
+	addOutputFlag(searchCmd)
+	rootCmd.AddCommand(searchCmd)
+}
@@ -0,0 +1,412 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/spf13/cobra"
+)
+
+var searchPluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "Manage search plugins",
+}
+
+var searchPluginsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed search plugins",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		plugins, err := cli.SearchPlugins(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list search plugins: %w", err)
+		}
+
+		if format == outputJSON {
+			return printJSON(plugins)
+		}
+
+		w := newTable()
+		_, _ = fmt.Fprintln(w, "NAME\tFULL NAME\tVERSION\tENABLED\tURL")
+		for _, p := range plugins {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\n", p.Name, p.FullName, p.Version, p.Enabled, p.URL)
+		}
+		return w.Flush()
+	},
+}
+
+var searchPluginsInstallCmd = &cobra.Command{
+	Use:   "install <url|path>...",
+	Short: "Install search plugins from URLs or paths on the qBittorrent host",
+	Long: `Install search plugins from URLs or local paths. qBittorrent fetches the sources itself and the
+API offers no upload, so a path must exist on the host (or in the container) qBittorrent runs on,
+not on the machine running qbcli.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.InstallSearchPlugins(ctx, args); err != nil {
+			return fmt.Errorf("failed to install search plugins: %w", err)
+		}
+
+		cli.Log.Info("Search plugins install requested successfully", "sources", len(args))
+		return nil
+	},
+}
+
+var searchPluginsUninstallCmd = &cobra.Command{
+	Use:   "uninstall <name>...",
+	Short: "Uninstall search plugins",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.UninstallSearchPlugins(ctx, args); err != nil {
+			return fmt.Errorf("failed to uninstall search plugins: %w", err)
+		}
+
+		cli.Log.Info("Search plugins uninstalled successfully", "names", args)
+		return nil
+	},
+}
+
+func newSearchPluginsEnableCmd(use string, short string, enable bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " <name>...",
+		Short: short,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := rootEnv.Context()
+			defer cancel()
+
+			cli, err := rootEnv.Client()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+
+			if err := cli.EnableSearchPlugins(ctx, args, enable); err != nil {
+				return fmt.Errorf("failed to %s search plugins: %w", use, err)
+			}
+
+			cli.Log.Info("Search plugins updated successfully", "names", args, "enabled", enable)
+			return nil
+		},
+	}
+}
+
+var searchPluginsUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update all search plugins to their latest versions",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.UpdateSearchPlugins(ctx); err != nil {
+			return fmt.Errorf("failed to update search plugins: %w", err)
+		}
+
+		cli.Log.Info("Search plugins update requested successfully")
+		return nil
+	},
+}
+
+var searchPluginsSyncCmd = &cobra.Command{
+	Use:   "sync -f <plugins.txt>",
+	Short: "Make the installed search plugins match a list file",
+	Long: `Make the installed and enabled search plugins match a list file.
+Each line of the file holds a plugin source, optionally followed by 'disabled'. Sources are URLs
+or paths on the host qBittorrent runs on, since qBittorrent fetches them itself. The plugin name is the source's file name without the .py extension.
+Blank lines and lines starting with '#' are ignored.
+
+Missing plugins are installed and plugins whose enabled state differs are enabled or disabled.
+Installed plugins absent from the file are only uninstalled with --prune. A file without plugins
+is refused, so that an empty or truncated file cannot uninstall every plugin.`,
+	Example: `  qbcli search plugins sync -f plugins.txt --dry-run
+  qbcli search plugins sync -f plugins.txt --prune`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		flags := cmd.Flags()
+		filePath, _ := flags.GetString("file")
+		dryRun, _ := flags.GetBool("dry-run")
+		prune, _ := flags.GetBool("prune")
+		wait, _ := flags.GetDuration("wait")
+
+		desired, err := readPluginsFile(filePath)
+		if err != nil {
+			return err
+		}
+		if len(desired) == 0 {
+			return fmt.Errorf("plugins file %s lists no plugins", filePath)
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		plugins, err := cli.SearchPlugins(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list search plugins: %w", err)
+		}
+
+		installed := map[string]client.SearchPlugin{}
+		for _, p := range plugins {
+			installed[p.Name] = p
+		}
+
+		var install, installNames, uninstall, enable, disable []string
+		wanted := map[string]bool{}
+		for _, p := range desired {
+			wanted[p.Name] = true
+			current, found := installed[p.Name]
+			switch {
+			case !found:
+				install = append(install, p.Source)
+				installNames = append(installNames, p.Name)
+				fmt.Printf("install\t%s\t%s\n", p.Name, redactPluginSource(p.Source))
+				if !p.Enabled {
+					disable = append(disable, p.Name)
+					fmt.Printf("disable\t%s\n", p.Name)
+				}
+			case current.Enabled != p.Enabled && p.Enabled:
+				enable = append(enable, p.Name)
+				fmt.Printf("enable\t%s\n", p.Name)
+			case current.Enabled != p.Enabled:
+				disable = append(disable, p.Name)
+				fmt.Printf("disable\t%s\n", p.Name)
+			}
+		}
+
+		if prune {
+			for _, p := range plugins {
+				if !wanted[p.Name] {
+					uninstall = append(uninstall, p.Name)
+					fmt.Printf("uninstall\t%s\n", p.Name)
+				}
+			}
+		}
+
+		if dryRun {
+			return nil
+		}
+
+		if len(uninstall) > 0 {
+			if err := cli.UninstallSearchPlugins(ctx, uninstall); err != nil {
+				return fmt.Errorf("failed to uninstall search plugins: %w", err)
+			}
+		}
+
+		if len(install) > 0 {
+			if err := cli.InstallSearchPlugins(ctx, install); err != nil {
+				return fmt.Errorf("failed to install search plugins: %w", err)
+			}
+			if err := waitForSearchPlugins(ctx, cli, installNames, wait); err != nil {
+				return err
+			}
+		}
+
+		if len(enable) > 0 {
+			if err := cli.EnableSearchPlugins(ctx, enable, true); err != nil {
+				return fmt.Errorf("failed to enable search plugins: %w", err)
+			}
+		}
+
+		if len(disable) > 0 {
+			if err := cli.EnableSearchPlugins(ctx, disable, false); err != nil {
+				return fmt.Errorf("failed to disable search plugins: %w", err)
+			}
+		}
+
+		cli.Log.Info("Search plugins synced successfully",
+			"installed", len(install), "uninstalled", len(uninstall), "enabled", len(enable), "disabled", len(disable))
+		return nil
+	},
+}
+
+type pluginEntry struct {
+	Name    string
+	Source  string
+	Enabled bool
+}
+
+func readPluginsFile(filePath string) ([]pluginEntry, error) {
+	var reader io.Reader
+	if filePath == "-" {
+		reader = os.Stdin
+	} else {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open plugins file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		reader = file
+	}
+
+	var entries []pluginEntry
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(reader)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		entry := pluginEntry{Source: fields[0], Name: pluginNameFromSource(fields[0]), Enabled: true}
+		switch {
+		case len(fields) == 2 && strings.EqualFold(fields[1], "disabled"):
+			entry.Enabled = false
+		case len(fields) > 1:
+			return nil, fmt.Errorf("plugins file line %d: unexpected %q", n, strings.Join(fields[1:], " "))
+		}
+
+		if entry.Name == "" {
+			return nil, fmt.Errorf("plugins file line %d: no plugin name in %s", n, redactPluginSource(entry.Source))
+		}
+		if seen[entry.Name] {
+			return nil, fmt.Errorf("plugins file line %d: duplicate plugin %s", n, entry.Name)
+		}
+		seen[entry.Name] = true
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read plugins file: %w", err)
+	}
+	return entries, nil
+}
+
+func pluginNameFromSource(source string) string {
+	name := filepath.Base(source)
+	if u, err := url.Parse(source); err == nil && u.Scheme != "" && u.Host != "" {
+		name = path.Base(u.Path)
+	}
+	name = strings.TrimSuffix(name, ".py")
+	if name == "." || name == "/" {
+		return ""
+	}
+	return name
+}
+
+// redactPluginSource strips the user info and query of a source URL for output, since they
+// can carry credentials. Paths are kept as they are.
+func redactPluginSource(source string) string {
+	u, err := url.Parse(source)
+	if err != nil {
+		return "<redacted>"
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return source
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+// waitForSearchPlugins waits for background installations to show up in the plugin list.
+func waitForSearchPlugins(ctx context.Context, cli *client.Client, names []string, wait time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	missing := names
+	for {
+		plugins, err := cli.SearchPlugins(ctx)
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("failed to list search plugins: %w", err)
+		}
+
+		if err == nil {
+			installed := map[string]bool{}
+			for _, p := range plugins {
+				installed[p.Name] = true
+			}
+
+			missing = nil
+			for _, name := range names {
+				if !installed[name] {
+					missing = append(missing, name)
+				}
+			}
+			if len(missing) == 0 {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("search plugins not installed after %s: %s", wait, strings.Join(missing, ", "))
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func init() {
+	addOutputFlag(searchPluginsListCmd)
+
+	searchPluginsSyncCmd.Flags().StringP("file", "f", "", "Path to the plugins list file or '-' for stdin")
+	searchPluginsSyncCmd.Flags().Bool("dry-run", false, "Only report what would change")
+	searchPluginsSyncCmd.Flags().Bool("prune", false, "Uninstall plugins missing from the file")
+	searchPluginsSyncCmd.Flags().Duration("wait", 30*time.Second, "Maximum time to wait for installations to complete")
+	_ = searchPluginsSyncCmd.MarkFlagRequired("file")
+
+	searchPluginsCmd.AddCommand(
+		searchPluginsListCmd,
+		searchPluginsInstallCmd,
+		searchPluginsUninstallCmd,
+		newSearchPluginsEnableCmd("enable", "Enable search plugins", true),
+		newSearchPluginsEnableCmd("disable", "Disable search plugins", false),
+		searchPluginsUpdateCmd,
+		searchPluginsSyncCmd,
+	)
+	searchCmd.AddCommand(searchPluginsCmd)
+}
@@ -4,13 +4,16 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/gstos/qbcli/internal/qb/client"
 	"github.com/spf13/cobra"
 )
 
 var setListeningPortCmd = &cobra.Command{
 	Use:   "setListeningPort <port>",
 	Short: "Set qBittorrent listening port",
-	Args:  cobra.ExactArgs(1), // Require exactly one argument
+	Long: `Set qBittorrent listening port. Nothing is written when the port is already set;
+otherwise the port is read back and the command fails if qBittorrent did not apply it.`,
+	Args: cobra.ExactArgs(1), // Require exactly one argument
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := rootEnv.Context()
 		defer cancel()
@@ -25,15 +28,34 @@ var setListeningPortCmd = &cobra.Command{
 			return fmt.Errorf("invalid port: %s", args[0])
 		}
 
-		if err := cli.SetListeningPort(ctx, port); err != nil {
+		changed, err := cli.EnsureListeningPort(ctx, port, listeningPortOptionsFromFlags(cmd))
+		if err != nil {
 			return fmt.Errorf("failed to set listening port: %w", err)
 		}
 
+		if !changed {
+			cli.Log.Info("Listening port already set", "port", port)
+			return nil
+		}
+
 		cli.Log.Info("Listening port set successfully", "port", port)
 		return nil
 	},
 }
 
+func addListeningPortFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("ensure-random-port-off", false, "Also turn off random_port, which would replace the port on restart")
+	cmd.Flags().Bool("ensure-upnp-off", false, "Also turn off upnp, which would map another port on the router")
+}
+
+func listeningPortOptionsFromFlags(cmd *cobra.Command) client.ListeningPortOptions {
+	var opts client.ListeningPortOptions
+	opts.DisableRandomPort, _ = cmd.Flags().GetBool("ensure-random-port-off")
+	opts.DisableUPnP, _ = cmd.Flags().GetBool("ensure-upnp-off")
+	return opts
+}
+
 func init() {
+	addListeningPortFlags(setListeningPortCmd)
 	rootCmd.AddCommand(setListeningPortCmd)
 }
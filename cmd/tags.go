@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/spf13/cobra"
+)
+
+var tagsCmd = &cobra.Command{
+	Use:     "tags",
+	Aliases: []string{"tag"},
+	Short:   "Manage torrent tags",
+}
+
+var tagsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tags",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		tags, err := cli.Tags(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list tags: %w", err)
+		}
+		sort.Strings(tags)
+
+		if format == outputJSON {
+			return printJSON(tags)
+		}
+
+		for _, tag := range tags {
+			fmt.Println(tag)
+		}
+		return nil
+	},
+}
+
+var tagsCreateCmd = &cobra.Command{
+	Use:   "create <tag>...",
+	Short: "Create tags",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.CreateTags(ctx, args); err != nil {
+			return fmt.Errorf("failed to create tags: %w", err)
+		}
+
+		cli.Log.Info("Tags created successfully", "tags", args)
+		return nil
+	},
+}
+
+var tagsDeleteCmd = &cobra.Command{
+	Use:   "delete <tag>...",
+	Short: "Delete tags",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.DeleteTags(ctx, args); err != nil {
+			return fmt.Errorf("failed to delete tags: %w", err)
+		}
+
+		cli.Log.Info("Tags deleted successfully", "tags", args)
+		return nil
+	},
+}
+
+var tagsAddCmd = newTorrentsActionCmd("add", "Add tags to torrents", nil,
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		names, _ := cmd.Flags().GetStringSlice("name")
+		return cli.AddTags(ctx, hashes, names)
+	})
+
+var tagsRemoveCmd = newTorrentsActionCmd("remove", "Remove tags from torrents", nil,
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		names, _ := cmd.Flags().GetStringSlice("name")
+		return cli.RemoveTags(ctx, hashes, names)
+	})
+
+func init() {
+	addOutputFlag(tagsListCmd)
+	tagsAddCmd.Flags().StringSlice("name", nil, "Tags to add")
+	tagsRemoveCmd.Flags().StringSlice("name", nil, "Tags to remove")
+	_ = tagsAddCmd.MarkFlagRequired("name")
+	_ = tagsRemoveCmd.MarkFlagRequired("name")
+
+	tagsCmd.AddCommand(tagsListCmd, tagsCreateCmd, tagsDeleteCmd, tagsAddCmd, tagsRemoveCmd)
+	rootCmd.AddCommand(tagsCmd)
+}
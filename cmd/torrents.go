@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var torrentsCmd = &cobra.Command{
+	Use:   "torrents",
+	Short: "Manage qBittorrent torrents",
+}
+
+func init() {
+	rootCmd.AddCommand(torrentsCmd)
+}
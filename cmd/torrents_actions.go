@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/spf13/cobra"
+)
+
+type torrentsAction func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error
+
+func newTorrentsActionCmd(use string, short string, aliases []string, action torrentsAction) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use + " [hash...|all]",
+		Aliases: aliases,
+		Short:   short,
+		Long: short + `.
+Torrents are selected by hash, by the keyword 'all', or by the --filter, --category and --tag flags.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := rootEnv.Context()
+			defer cancel()
+
+			cli, err := rootEnv.Client()
+			if err != nil {
+				return fmt.Errorf("failed to create client: %w", err)
+			}
+
+			hashes, err := selectTorrentHashes(ctx, cli, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			if len(hashes) == 0 {
+				cli.Log.Warn("No torrents matched the selection")
+				return nil
+			}
+
+			if err := action(ctx, cli, cmd, hashes); err != nil {
+				return fmt.Errorf("failed to %s torrents: %w", use, err)
+			}
+
+			cli.Log.Info("Torrents updated successfully", "action", use, "count", len(hashes))
+			return nil
+		},
+	}
+	addTorrentFilterFlags(cmd)
+	return cmd
+}
+
+var torrentsStopCmd = newTorrentsActionCmd("stop", "Stop (pause) torrents", []string{"pause"},
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		return cli.StopTorrents(ctx, hashes)
+	})
+
+var torrentsStartCmd = newTorrentsActionCmd("start", "Start (resume) torrents", []string{"resume"},
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		return cli.StartTorrents(ctx, hashes)
+	})
+
+var torrentsRecheckCmd = newTorrentsActionCmd("recheck", "Recheck torrents", nil,
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		return cli.RecheckTorrents(ctx, hashes)
+	})
+
+var torrentsReannounceCmd = newTorrentsActionCmd("reannounce", "Reannounce torrents to their trackers", nil,
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		return cli.ReannounceTorrents(ctx, hashes)
+	})
+
+var torrentsForceStartCmd = newTorrentsActionCmd("force-start", "Force start torrents, ignoring queue limits", nil,
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		disable, _ := cmd.Flags().GetBool("disable")
+		return cli.SetForceStart(ctx, hashes, !disable)
+	})
+
+var torrentsDeleteCmd = newTorrentsActionCmd("delete", "Delete torrents", []string{"rm"},
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		deleteFiles, _ := cmd.Flags().GetBool("delete-files")
+		yes, _ := cmd.Flags().GetBool("yes")
+		args := cmd.Flags().Args()
+		bulk := hasTorrentFilter(cmd) || (len(args) == 1 && args[0] == client.AllTorrents)
+
+		if deleteFiles && bulk && !yes {
+			if !isTerminal(os.Stdin) {
+				return fmt.Errorf("deleting the data of torrents selected by 'all' or filters requires --yes")
+			}
+			selection := fmt.Sprintf("%d torrents", len(hashes))
+			if len(hashes) == 1 && hashes[0] == client.AllTorrents {
+				selection = "all torrents"
+			}
+			confirmed, err := confirmDeleteFiles(os.Stdin, os.Stderr, selection)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return fmt.Errorf("deletion not confirmed")
+			}
+		}
+		return cli.DeleteTorrents(ctx, hashes, deleteFiles)
+	})
+
+// confirmDeleteFiles asks whether to delete the selection with its data; only "y" or "yes" confirm.
+func confirmDeleteFiles(in io.Reader, out io.Writer, selection string) (bool, error) {
+	_, _ = fmt.Fprintf(out, "Delete %s and their downloaded data? [y/N] ", selection)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func init() {
+	torrentsForceStartCmd.Flags().Bool("disable", false, "Remove the force start flag instead of setting it")
+	torrentsDeleteCmd.Flags().Bool("delete-files", false, "Also delete downloaded data")
+	torrentsDeleteCmd.Flags().BoolP("yes", "y", false, "Delete data of torrents selected by 'all' or filters without confirmation")
+
+	torrentsCmd.AddCommand(
+		torrentsStopCmd,
+		torrentsStartCmd,
+		torrentsRecheckCmd,
+		torrentsReannounceCmd,
+		torrentsForceStartCmd,
+		torrentsDeleteCmd,
+	)
+}
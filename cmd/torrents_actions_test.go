@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfirmDeleteFiles(t *testing.T) {
+	tests := map[string]bool{
+		"y\n":   true,
+		"YES\n": true,
+		"n\n":   false,
+		"\n":    false,
+		"":      false,
+		"yes":   true,
+	}
+	for in, want := range tests {
+		var out bytes.Buffer
+		got, err := confirmDeleteFiles(strings.NewReader(in), &out, "3 torrents")
+		if err != nil || got != want {
+			t.Errorf("confirmDeleteFiles(%q) = %v, %v; want %v", in, got, err, want)
+		}
+		if !strings.Contains(out.String(), "Delete 3 torrents") {
+			t.Errorf("prompt = %q, want it to name the selection", out.String())
+		}
+	}
+}
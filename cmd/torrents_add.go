@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/units"
+	"github.com/spf13/cobra"
+)
+
+var torrentsAddCmd = &cobra.Command{
+	Use:   "add [file|url|-]...",
+	Short: "Add torrents from .torrent files, magnet links or URLs",
+	Long: `Add torrents from .torrent files, magnet links or HTTP(S) URLs.
+Use '-' to read from stdin: either a single .torrent file or a list of URLs, one per line.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		stdinArgs := 0
+		for _, arg := range args {
+			if arg == "-" {
+				stdinArgs++
+			}
+		}
+		if stdinArgs > 1 {
+			return fmt.Errorf("'-' given %d times: stdin can only be read once", stdinArgs)
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		opts, err := addTorrentsOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		for _, arg := range args {
+			switch {
+			case arg == "-":
+				urls, files, err := readTorrentsFrom(os.Stdin, "stdin.torrent")
+				if err != nil {
+					return fmt.Errorf("failed to read stdin: %w", err)
+				}
+				opts.URLs = append(opts.URLs, urls...)
+				opts.Files = append(opts.Files, files...)
+			case isTorrentURL(arg):
+				opts.URLs = append(opts.URLs, arg)
+			default:
+				content, err := os.ReadFile(arg)
+				if err != nil {
+					return fmt.Errorf("failed to read torrent file: %w", err)
+				}
+				opts.Files = append(opts.Files, client.TorrentFile{Name: filepath.Base(arg), Content: content})
+			}
+		}
+
+		if err := cli.AddTorrents(ctx, opts); err != nil {
+			return fmt.Errorf("failed to add torrents: %w", err)
+		}
+
+		cli.Log.Info("Torrents added successfully", "urls", len(opts.URLs), "files", len(opts.Files))
+		return nil
+	},
+}
+
+func isTorrentURL(s string) bool {
+	for _, prefix := range []string{"magnet:", "http://", "https://", "bc://bt/"} {
+		if strings.HasPrefix(strings.ToLower(s), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readTorrentsFrom reads either a bencoded .torrent file or a newline separated list of URLs.
+func readTorrentsFrom(r io.Reader, name string) ([]string, []client.TorrentFile, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Bencoded torrent files are dictionaries, which always start with 'd'
+	if bytes.HasPrefix(content, []byte("d")) {
+		return nil, []client.TorrentFile{{Name: name, Content: content}}, nil
+	}
+
+	var urls []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !isTorrentURL(line) {
+			return nil, nil, fmt.Errorf("not a magnet link or URL: %s", line)
+		}
+		urls = append(urls, line)
+	}
+	return urls, nil, scanner.Err()
+}
+
+func addTorrentsOptionsFromFlags(cmd *cobra.Command) (client.AddTorrentsOptions, error) {
+	flags := cmd.Flags()
+
+	var opts client.AddTorrentsOptions
+	opts.SavePath, _ = flags.GetString("save-path")
+	opts.DownloadPath, _ = flags.GetString("download-path")
+	opts.Category, _ = flags.GetString("category")
+	opts.Tags, _ = flags.GetStringSlice("tags")
+	opts.Rename, _ = flags.GetString("rename")
+	opts.Cookie, _ = flags.GetString("cookie")
+	opts.SkipChecking, _ = flags.GetBool("skip-checking")
+	opts.SequentialDownload, _ = flags.GetBool("sequential")
+	opts.FirstLastPiecePrio, _ = flags.GetBool("first-last-piece")
+
+	if value, _ := flags.GetString("up-limit"); value != "" {
+		limit, err := units.ParseRate(value)
+		if err != nil {
+			return opts, err
+		}
+		opts.UpLimit = limit
+	}
+
+	if value, _ := flags.GetString("dl-limit"); value != "" {
+		limit, err := units.ParseRate(value)
+		if err != nil {
+			return opts, err
+		}
+		opts.DlLimit = limit
+	}
+
+	if layout, _ := flags.GetString("content-layout"); layout != "" {
+		switch strings.ToLower(layout) {
+		case "original":
+			opts.ContentLayout = "Original"
+		case "subfolder":
+			opts.ContentLayout = "Subfolder"
+		case "nosubfolder":
+			opts.ContentLayout = "NoSubfolder"
+		default:
+			return opts, fmt.Errorf("invalid content layout: %s", layout)
+		}
+	}
+
+	if flags.Changed("stopped") || flags.Changed("paused") {
+		stopped, _ := flags.GetBool("stopped")
+		paused, _ := flags.GetBool("paused")
+		value := stopped || paused
+		opts.Stopped = &value
+	}
+
+	if flags.Changed("auto-tmm") {
+		autoTMM, _ := flags.GetBool("auto-tmm")
+		opts.AutoTMM = &autoTMM
+	}
+
+	if flags.Changed("ratio-limit") {
+		value, _ := flags.GetString("ratio-limit")
+		ratio, err := parseRatioLimit(value)
+		if err != nil {
+			return opts, err
+		}
+		opts.RatioLimit = &ratio
+	}
+
+	if flags.Changed("seeding-time-limit") {
+		value, _ := flags.GetString("seeding-time-limit")
+		minutes, err := parseTimeLimit(value)
+		if err != nil {
+			return opts, err
+		}
+		opts.SeedingTimeLimit = &minutes
+	}
+
+	if flags.Changed("inactive-seeding-time-limit") {
+		value, _ := flags.GetString("inactive-seeding-time-limit")
+		minutes, err := parseTimeLimit(value)
+		if err != nil {
+			return opts, err
+		}
+		opts.InactiveSeedingTimeLimit = &minutes
+	}
+
+	return opts, nil
+}
+
+func init() {
+	flags := torrentsAddCmd.Flags()
+	flags.String("save-path", "", "Download folder")
+	flags.String("download-path", "", "Incomplete download folder")
+	flags.String("category", "", "Category for the torrents")
+	flags.StringSlice("tags", nil, "Tags for the torrents")
+	flags.String("rename", "", "Rename torrent")
+	flags.String("cookie", "", "Cookie sent to download the .torrent file")
+	flags.String("content-layout", "", "Content layout: original, subfolder, nosubfolder")
+	flags.Bool("stopped", false, "Add torrents in the stopped (paused) state")
+	flags.Bool("paused", false, "Alias for --stopped")
+	flags.Bool("skip-checking", false, "Skip hash checking")
+	flags.Bool("auto-tmm", false, "Use automatic torrent management")
+	flags.Bool("sequential", false, "Enable sequential download")
+	flags.Bool("first-last-piece", false, "Prioritize download of first and last pieces")
+	flags.String("up-limit", "", "Upload speed limit (e.g. 1MiB)")
+	flags.String("dl-limit", "", "Download speed limit (e.g. 5MiB)")
+	flags.String("ratio-limit", "", "Share ratio limit: a number, 'global' or 'unlimited'")
+	flags.String("seeding-time-limit", "", "Seeding time limit: a duration (e.g. 72h), 'global' or 'unlimited'")
+	flags.String("inactive-seeding-time-limit", "", "Inactive seeding time limit: a duration (e.g. 24h), 'global' or 'unlimited'")
+	_ = flags.MarkHidden("paused")
+	torrentsCmd.AddCommand(torrentsAddCmd)
+}
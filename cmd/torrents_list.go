@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/units"
+	"github.com/spf13/cobra"
+)
+
+var torrentsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List torrents",
+	Long: `List torrents using the filters supported by qBittorrent (torrents/info).
+Use --category "" or --tag "" to select torrents without category or tag.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		opts, err := listTorrentsOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		torrents, err := cli.ListTorrents(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list torrents: %w", err)
+		}
+
+		if format == outputJSON {
+			return printJSON(torrents)
+		}
+		return printTorrentsTable(torrents)
+	},
+}
+
+func listTorrentsOptionsFromFlags(cmd *cobra.Command) (client.ListTorrentsOptions, error) {
+	flags := cmd.Flags()
+
+	opts := torrentFilterFromFlags(cmd)
+	opts.Sort, _ = flags.GetString("sort")
+	opts.Reverse, _ = flags.GetBool("reverse")
+	opts.Limit, _ = flags.GetInt("limit")
+	opts.Offset, _ = flags.GetInt("offset")
+	opts.Hashes, _ = flags.GetStringSlice("hashes")
+
+	if opts.Limit < 0 {
+		return opts, fmt.Errorf("invalid limit: %d", opts.Limit)
+	}
+
+	return opts, nil
+}
+
+func printTorrentsTable(torrents []client.Torrent) error {
+	w := newTable()
+	_, _ = fmt.Fprintln(w, "HASH\tNAME\tSTATE\tPROGRESS\tSIZE\tDOWN\tUP\tETA\tRATIO\tCATEGORY\tTAGS")
+	for _, t := range torrents {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%.2f\t%s\t%s\n",
+			t.Hash[:min(len(t.Hash), 8)],
+			truncate(t.Name, 50),
+			t.State,
+			units.FormatPercent(t.Progress),
+			units.FormatBytes(t.Size),
+			units.FormatRate(t.DlSpeed),
+			units.FormatRate(t.UpSpeed),
+			units.FormatETA(t.ETA),
+			t.Ratio,
+			t.Category,
+			t.Tags,
+		)
+	}
+	return w.Flush()
+}
+
+func init() {
+	addTorrentFilterFlags(torrentsListCmd)
+	torrentsListCmd.Flags().StringSlice("hashes", nil, "Only torrents with these hashes")
+	torrentsListCmd.Flags().String("sort", "", "Sort by torrent field (e.g. name, added_on, ratio)")
+	torrentsListCmd.Flags().Bool("reverse", false, "Reverse sort order")
+	torrentsListCmd.Flags().Int("limit", 0, "Limit the number of torrents returned (0 for no limit)")
+	torrentsListCmd.Flags().Int("offset", 0, "Offset the list of torrents (negative counts from the end)")
+	addOutputFlag(torrentsListCmd)
+	torrentsCmd.AddCommand(torrentsListCmd)
+}
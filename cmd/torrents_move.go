@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/spf13/cobra"
+)
+
+var torrentsMoveCmd = &cobra.Command{
+	Use:   "move [hash...|all] --to <dir>",
+	Short: "Move torrent data to another directory",
+	Long: `Move the data of torrents to another directory (torrents/setLocation).
+Torrents are selected by hash, by the keyword 'all', or by the --filter, --category and --tag flags.
+qBittorrent disables automatic torrent management for the moved torrents.
+With --wait, the command polls the torrents and reports each one as it finishes moving,
+for up to --wait-timeout.`,
+	Example: `  qbcli torrents move --category linux --to /mnt/disk2/linux --wait
+  qbcli torrents move <hash> --to /mnt/disk2/other`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rootCtx, cancel := rootEnv.Context()
+		defer cancel()
+
+		ctx, stop := signal.NotifyContext(rootCtx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		flags := cmd.Flags()
+		to, _ := flags.GetString("to")
+		wait, _ := flags.GetBool("wait")
+		interval, _ := flags.GetDuration("interval")
+		waitTimeout, _ := flags.GetDuration("wait-timeout")
+
+		if wait && interval <= 0 {
+			return fmt.Errorf("invalid interval: %s", interval)
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		hashes, err := selectTorrentHashes(ctx, cli, cmd, args)
+		if err != nil {
+			return err
+		}
+
+		if len(hashes) == 0 {
+			cli.Log.Warn("No torrents matched the selection")
+			return nil
+		}
+
+		if err := cli.SetLocation(ctx, hashes, to); err != nil {
+			return fmt.Errorf("failed to move torrents: %w", err)
+		}
+
+		if !wait {
+			cli.Log.Info("Torrents move started successfully", "to", to, "count", len(hashes))
+			return nil
+		}
+
+		if waitTimeout > 0 {
+			var cancelWait context.CancelFunc
+			ctx, cancelWait = context.WithTimeout(ctx, waitTimeout)
+			defer cancelWait()
+		}
+
+		if err := waitForMove(ctx, cli, hashes, to, interval); err != nil {
+			return err
+		}
+
+		cli.Log.Info("Torrents moved successfully", "to", to, "count", len(hashes))
+		return nil
+	},
+}
+
+// waitForMove polls the torrents until none of them is moving and all report the new save path.
+func waitForMove(ctx context.Context, cli *client.Client, hashes []string, to string, interval time.Duration) error {
+	opts := client.ListTorrentsOptions{}
+	if !(len(hashes) == 1 && hashes[0] == client.AllTorrents) {
+		opts.Hashes = hashes
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	done := map[string]bool{}
+	total := -1
+	for {
+		torrents, err := cli.ListTorrents(ctx, opts)
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("failed to get torrent state: %w", err)
+		}
+
+		if err == nil {
+			if total < 0 {
+				total = len(torrents)
+			}
+
+			var errs []error
+			moving := 0
+			for _, t := range torrents {
+				switch {
+				case done[t.Hash]:
+				case t.State == "error" || t.State == "missingFiles":
+					errs = append(errs, fmt.Errorf("%s: torrent in %s state", t.Name, t.State))
+				case t.State != "moving" && samePath(t.SavePath, to):
+					done[t.Hash] = true
+					_, _ = fmt.Fprintf(os.Stderr, "moved\t%d/%d\t%s\n", len(done), total, t.Name)
+				default:
+					moving++
+				}
+			}
+
+			if len(errs) > 0 {
+				return fmt.Errorf("failed to move torrents: %w", errors.Join(errs...))
+			}
+			if moving == 0 {
+				return nil
+			}
+			cli.Log.Debug("torrents still moving", "moving", moving, "done", len(done), "total", total)
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("torrents still moving after timeout: %d of %d done", len(done), total)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// samePath compares paths as qBittorrent may report them: with a trailing or Windows separator.
+// Paths live on qBittorrent's host, so they are only cleaned lexically, never resolved where qbcli runs.
+func samePath(a string, b string) bool {
+	return normalizePath(a) == normalizePath(b)
+}
+
+func normalizePath(p string) string {
+	return path.Clean(strings.ReplaceAll(p, `\`, "/"))
+}
+
+var torrentsDownloadPathCmd = newTorrentsActionCmd("download-path", "Set the incomplete download path of torrents", nil,
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		return cli.SetDownloadPath(ctx, hashes, path)
+	})
+
+var torrentsSavePathCmd = newTorrentsActionCmd("save-path", "Set the save path of torrents", nil,
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		return cli.SetSavePath(ctx, hashes, path)
+	})
+
+var torrentsAutoTMMCmd = newTorrentsActionCmd("auto-tmm", "Enable automatic torrent management", nil,
+	func(ctx context.Context, cli *client.Client, cmd *cobra.Command, hashes []string) error {
+		disable, _ := cmd.Flags().GetBool("disable")
+		return cli.SetAutoManagement(ctx, hashes, !disable)
+	})
+
+func init() {
+	flags := torrentsMoveCmd.Flags()
+	flags.String("to", "", "Destination directory")
+	flags.Bool("wait", false, "Wait until the torrents finish moving, reporting progress")
+	flags.Duration("interval", 2*time.Second, "State polling interval with --wait")
+	flags.Duration("wait-timeout", time.Hour, "Maximum time to wait with --wait (0 for no limit)")
+	_ = torrentsMoveCmd.MarkFlagRequired("to")
+	addTorrentFilterFlags(torrentsMoveCmd)
+
+	torrentsSavePathCmd.Flags().String("path", "", "New save path")
+	_ = torrentsSavePathCmd.MarkFlagRequired("path")
+	torrentsDownloadPathCmd.Flags().String("path", "", "New incomplete download path (empty to disable)")
+	torrentsAutoTMMCmd.Flags().Bool("disable", false, "Disable automatic torrent management instead of enabling it")
+
+	torrentsCmd.AddCommand(torrentsMoveCmd, torrentsSavePathCmd, torrentsDownloadPathCmd, torrentsAutoTMMCmd)
+}
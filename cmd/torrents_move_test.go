@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSamePath(t *testing.T) {
+	// A symlink where qbcli runs says nothing about the paths on qBittorrent's host
+	dir := t.TempDir()
+	target := filepath.Join(dir, "disk2")
+	link := filepath.Join(dir, "media")
+	if err := os.Mkdir(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"/data/linux", "/data/linux/", true},
+		{"/data//linux", "/data/./linux", true},
+		{`D:\Downloads\linux\`, "D:/Downloads/linux", true},
+		{"/data/missing/../linux", "/data/linux", true},
+		{link, target + "/", false},
+		{"/data/linux", "/data/linux2", false},
+	}
+
+	for _, tt := range tests {
+		if got := samePath(tt.a, tt.b); got != tt.want {
+			t.Errorf("samePath(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
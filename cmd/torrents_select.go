@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/spf13/cobra"
+)
+
+func addTorrentFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("filter", "", "State filter: all, downloading, seeding, completed, stopped, running, active, inactive, stalled, errored...")
+	cmd.Flags().String("category", "", "Only torrents in this category (empty string for uncategorized)")
+	cmd.Flags().String("tag", "", "Only torrents with this tag (empty string for untagged)")
+}
+
+func torrentFilterFromFlags(cmd *cobra.Command) client.ListTorrentsOptions {
+	flags := cmd.Flags()
+
+	var opts client.ListTorrentsOptions
+	opts.Filter, _ = flags.GetString("filter")
+
+	if flags.Changed("category") {
+		category, _ := flags.GetString("category")
+		opts.Category = &category
+	}
+
+	if flags.Changed("tag") {
+		tag, _ := flags.GetString("tag")
+		opts.Tag = &tag
+	}
+
+	return opts
+}
+
+func hasTorrentFilter(cmd *cobra.Command) bool {
+	flags := cmd.Flags()
+	return flags.Changed("filter") || flags.Changed("category") || flags.Changed("tag")
+}
+
+// selectTorrentHashes resolves the torrents a command acts upon: explicit hashes given as arguments,
+// the keyword "all", or the torrents matching the filter flags (optionally restricted to the given hashes).
+func selectTorrentHashes(ctx context.Context, cli *client.Client, cmd *cobra.Command, args []string) ([]string, error) {
+	all := len(args) == 1 && args[0] == client.AllTorrents
+
+	if !hasTorrentFilter(cmd) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("no torrents selected: pass hashes, 'all' or filter flags")
+		}
+		return args, nil
+	}
+
+	opts := torrentFilterFromFlags(cmd)
+	if !all {
+		opts.Hashes = args
+	}
+
+	torrents, err := cli.ListTorrents(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve torrent selection: %w", err)
+	}
+
+	hashes := make([]string, 0, len(torrents))
+	for _, t := range torrents {
+		hashes = append(hashes, t.Hash)
+	}
+
+	cli.Log.Debug("torrent selection resolved", "count", len(hashes))
+	return hashes, nil
+}
+
+// selectTorrents resolves the selection like selectTorrentHashes, but always queries torrents/info so
+// callers get the full torrent records. With no arguments nor filter flags, every torrent is selected.
+func selectTorrents(ctx context.Context, cli *client.Client, cmd *cobra.Command, args []string) ([]client.Torrent, error) {
+	opts := torrentFilterFromFlags(cmd)
+	if len(args) > 0 && !(len(args) == 1 && args[0] == client.AllTorrents) {
+		opts.Hashes = args
+	}
+
+	torrents, err := cli.ListTorrents(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve torrent selection: %w", err)
+	}
+
+	cli.Log.Debug("torrent selection resolved", "count", len(torrents))
+	return torrents, nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var trackersCmd = &cobra.Command{
+	Use:   "trackers",
+	Short: "Manage torrent trackers",
+}
+
+var trackersListCmd = &cobra.Command{
+	Use:   "list <hash>",
+	Short: "List trackers of a torrent",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		trackers, err := cli.Trackers(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to list trackers: %w", err)
+		}
+
+		if format == outputJSON {
+			return printJSON(trackers)
+		}
+
+		w := newTable()
+		_, _ = fmt.Fprintln(w, "TIER\tSTATUS\tSEEDS\tPEERS\tLEECHES\tURL\tMESSAGE")
+		for _, t := range trackers {
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\t%s\t%s\n",
+				t.Tier, t.StatusString(), t.NumSeeds, t.NumPeers, t.NumLeeches, t.URL, t.Msg)
+		}
+		return w.Flush()
+	},
+}
+
+var trackersAddCmd = &cobra.Command{
+	Use:   "add <hash> <url>...",
+	Short: "Add trackers to a torrent",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.AddTrackers(ctx, args[0], args[1:]); err != nil {
+			return fmt.Errorf("failed to add trackers: %w", err)
+		}
+
+		cli.Log.Info("Trackers added successfully", "hash", args[0], "count", len(args)-1)
+		return nil
+	},
+}
+
+var trackersEditCmd = &cobra.Command{
+	Use:   "edit <hash> <orig-url> <new-url>",
+	Short: "Replace a tracker URL of a torrent",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.EditTracker(ctx, args[0], args[1], args[2]); err != nil {
+			return fmt.Errorf("failed to edit tracker: %w", err)
+		}
+
+		cli.Log.Info("Tracker edited successfully", "hash", args[0])
+		return nil
+	},
+}
+
+var trackersRemoveCmd = &cobra.Command{
+	Use:   "remove <hash> <url>...",
+	Short: "Remove trackers from a torrent",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if err := cli.RemoveTrackers(ctx, args[0], args[1:]); err != nil {
+			return fmt.Errorf("failed to remove trackers: %w", err)
+		}
+
+		cli.Log.Info("Trackers removed successfully", "hash", args[0], "count", len(args)-1)
+		return nil
+	},
+}
+
+func init() {
+	addOutputFlag(trackersListCmd)
+	trackersCmd.AddCommand(trackersListCmd, trackersAddCmd, trackersEditCmd, trackersRemoveCmd)
+	rootCmd.AddCommand(trackersCmd)
+}
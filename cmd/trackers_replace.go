@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/spf13/cobra"
+)
+
+type trackerChange struct {
+	Hash   string `json:"hash"`
+	Name   string `json:"name"`
+	OldURL string `json:"old_url"`
+	NewURL string `json:"new_url"`
+	Error  string `json:"error,omitempty"`
+}
+
+var trackersReplaceCmd = &cobra.Command{
+	Use:   "replace [hash...|all] --from <regex> --to <template>",
+	Short: "Rewrite tracker URLs matching a regular expression",
+	Long: `Rewrite tracker URLs matching a regular expression across torrents, e.g. to rotate a passkey.
+The template follows Go regexp expansion syntax: $1 or ${name} refer to capture groups.
+All torrents are scanned unless hashes or the --filter, --category and --tag flags are given.`,
+	Example: `  qbcli trackers replace --from 'passkey=[0-9a-f]+' --to 'passkey=NEWKEY' --dry-run
+  qbcli trackers replace --from '^https://old\.example/(.*)$' --to 'https://new.example/$1'`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		pattern, err := regexp.Compile(from)
+		if err != nil {
+			return fmt.Errorf("invalid --from expression: %w", err)
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		torrents, err := selectTorrents(ctx, cli, cmd, args)
+		if err != nil {
+			return err
+		}
+
+		var changes []trackerChange
+		var errs []error
+		for _, t := range torrents {
+			trackers, err := cli.Trackers(ctx, t.Hash)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", t.Hash, err))
+				continue
+			}
+
+			for _, tracker := range trackers {
+				if tracker.IsPseudo() || !pattern.MatchString(tracker.URL) {
+					continue
+				}
+
+				change := trackerChange{
+					Hash:   t.Hash,
+					Name:   t.Name,
+					OldURL: tracker.URL,
+					NewURL: pattern.ReplaceAllString(tracker.URL, to),
+				}
+				if change.NewURL == change.OldURL {
+					continue
+				}
+
+				if !dryRun {
+					if err := cli.EditTracker(ctx, t.Hash, change.OldURL, change.NewURL); err != nil {
+						change.Error = err.Error()
+						errs = append(errs, fmt.Errorf("%s: %w", t.Hash, err))
+					}
+				}
+				changes = append(changes, change)
+			}
+		}
+
+		if format == outputJSON {
+			if err := printJSON(changes); err != nil {
+				return err
+			}
+		} else if err := printTrackerChanges(changes, dryRun); err != nil {
+			return err
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to replace some trackers: %w", errors.Join(errs...))
+		}
+
+		cli.Log.Info("Trackers replaced successfully", "scanned", len(torrents), "changed", len(changes), "dryRun", dryRun)
+		return nil
+	},
+}
+
+func printTrackerChanges(changes []trackerChange, dryRun bool) error {
+	if len(changes) == 0 {
+		fmt.Println("No tracker URLs matched.")
+		return nil
+	}
+
+	w := newTable()
+	_, _ = fmt.Fprintln(w, "HASH\tNAME\tOLD URL\tNEW URL\tRESULT")
+	for _, c := range changes {
+		result := "replaced"
+		switch {
+		case dryRun:
+			result = "would replace"
+		case c.Error != "":
+			result = "failed"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Hash[:min(len(c.Hash), 8)], truncate(c.Name, 40), c.OldURL, c.NewURL, result)
+	}
+	return w.Flush()
+}
+
+func init() {
+	trackersReplaceCmd.Flags().String("from", "", "Regular expression matching the tracker URLs to rewrite")
+	trackersReplaceCmd.Flags().String("to", "", "Replacement template ($1, ${name} expand capture groups)")
+	trackersReplaceCmd.Flags().Bool("dry-run", false, "Only report what would change")
+	_ = trackersReplaceCmd.MarkFlagRequired("from")
+	_ = trackersReplaceCmd.MarkFlagRequired("to")
+	addTorrentFilterFlags(trackersReplaceCmd)
+	addOutputFlag(trackersReplaceCmd)
+	trackersCmd.AddCommand(trackersReplaceCmd)
+}
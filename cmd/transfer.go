@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gstos/qbcli/internal/units"
+	"github.com/spf13/cobra"
+)
+
+var transferCmd = &cobra.Command{
+	Use:   "transfer",
+	Short: "Global transfer information and speed limits",
+}
+
+type transferStatus struct {
+	ConnectionStatus string `json:"connection_status"`
+	DHTNodes         int    `json:"dht_nodes"`
+	DownloadSpeed    int64  `json:"download_speed"`
+	UploadSpeed      int64  `json:"upload_speed"`
+	Downloaded       int64  `json:"downloaded"`
+	Uploaded         int64  `json:"uploaded"`
+	DownloadLimit    int64  `json:"download_limit"`
+	UploadLimit      int64  `json:"upload_limit"`
+	AltSpeedLimits   bool   `json:"alt_speed_limits"`
+}
+
+var transferStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show global transfer speeds, totals and limits",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		info, err := cli.TransferInfo(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get transfer info: %w", err)
+		}
+
+		alt, err := cli.AltSpeedLimitsEnabled(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get speed limits mode: %w", err)
+		}
+
+		status := transferStatus{
+			ConnectionStatus: info.ConnectionStatus,
+			DHTNodes:         info.DHTNodes,
+			DownloadSpeed:    info.DlInfoSpeed,
+			UploadSpeed:      info.UpInfoSpeed,
+			Downloaded:       info.DlInfoData,
+			Uploaded:         info.UpInfoData,
+			DownloadLimit:    info.DlRateLimit,
+			UploadLimit:      info.UpRateLimit,
+			AltSpeedLimits:   alt,
+		}
+
+		if format == outputJSON {
+			return printJSON(status)
+		}
+
+		w := newTable()
+		_, _ = fmt.Fprintf(w, "Connection:\t%s\n", status.ConnectionStatus)
+		_, _ = fmt.Fprintf(w, "DHT nodes:\t%d\n", status.DHTNodes)
+		_, _ = fmt.Fprintf(w, "Download:\t%s (%s this session)\n", units.FormatRate(status.DownloadSpeed), units.FormatBytes(status.Downloaded))
+		_, _ = fmt.Fprintf(w, "Upload:\t%s (%s this session)\n", units.FormatRate(status.UploadSpeed), units.FormatBytes(status.Uploaded))
+		_, _ = fmt.Fprintf(w, "Download limit:\t%s\n", formatRateLimit(status.DownloadLimit))
+		_, _ = fmt.Fprintf(w, "Upload limit:\t%s\n", formatRateLimit(status.UploadLimit))
+		_, _ = fmt.Fprintf(w, "Alternative limits:\t%s\n", onOff(status.AltSpeedLimits))
+		return w.Flush()
+	},
+}
+
+var transferLimitCmd = &cobra.Command{
+	Use:   "limit",
+	Short: "Show or set global speed limits",
+	Long: `Show or set global speed limits.
+Rates accept units such as 5MiB, 800K or 1.5MB/s; use 0 or 'unlimited' to remove a limit.
+Limits apply to the active mode, so --alt is applied before --down and --up.
+Without flags, the current limits are printed.`,
+	Example: `  qbcli transfer limit --down 5MiB --up 1MiB
+  qbcli transfer limit --alt on`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := rootEnv.Context()
+		defer cancel()
+
+		flags := cmd.Flags()
+
+		var down, up int64
+		var err error
+		if flags.Changed("down") {
+			value, _ := flags.GetString("down")
+			if down, err = units.ParseRate(value); err != nil {
+				return err
+			}
+		}
+		if flags.Changed("up") {
+			value, _ := flags.GetString("up")
+			if up, err = units.ParseRate(value); err != nil {
+				return err
+			}
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		if flags.Changed("alt") {
+			value, _ := flags.GetString("alt")
+			switch strings.ToLower(value) {
+			case "on", "true", "1":
+				err = cli.SetAltSpeedLimits(ctx, true)
+			case "off", "false", "0":
+				err = cli.SetAltSpeedLimits(ctx, false)
+			case "toggle":
+				err = cli.ToggleSpeedLimitsMode(ctx)
+			default:
+				return fmt.Errorf("invalid --alt value: %s", value)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to set speed limits mode: %w", err)
+			}
+		}
+
+		if flags.Changed("down") {
+			if err := cli.SetDownloadLimit(ctx, down); err != nil {
+				return fmt.Errorf("failed to set download limit: %w", err)
+			}
+		}
+
+		if flags.Changed("up") {
+			if err := cli.SetUploadLimit(ctx, up); err != nil {
+				return fmt.Errorf("failed to set upload limit: %w", err)
+			}
+		}
+
+		if flags.Changed("alt") || flags.Changed("down") || flags.Changed("up") {
+			cli.Log.Info("Speed limits updated successfully")
+			return nil
+		}
+
+		down, err = cli.DownloadLimit(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get download limit: %w", err)
+		}
+
+		up, err = cli.UploadLimit(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get upload limit: %w", err)
+		}
+
+		alt, err := cli.AltSpeedLimitsEnabled(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get speed limits mode: %w", err)
+		}
+
+		w := newTable()
+		_, _ = fmt.Fprintf(w, "Download limit:\t%s\n", formatRateLimit(down))
+		_, _ = fmt.Fprintf(w, "Upload limit:\t%s\n", formatRateLimit(up))
+		_, _ = fmt.Fprintf(w, "Alternative limits:\t%s\n", onOff(alt))
+		return w.Flush()
+	},
+}
+
+func formatRateLimit(limit int64) string {
+	if limit <= 0 {
+		return "unlimited"
+	}
+	return units.FormatRate(limit)
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+func init() {
+	addOutputFlag(transferStatusCmd)
+	transferLimitCmd.Flags().String("down", "", "Global download limit (e.g. 5MiB, 0 for unlimited)")
+	transferLimitCmd.Flags().String("up", "", "Global upload limit (e.g. 1MiB, 0 for unlimited)")
+	transferLimitCmd.Flags().String("alt", "", "Alternative speed limits: on, off, toggle")
+
+	transferCmd.AddCommand(transferStatusCmd, transferLimitCmd)
+	rootCmd.AddCommand(transferCmd)
+}
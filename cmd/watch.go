@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/qb/maindata"
+	"github.com/gstos/qbcli/internal/units"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Continuously display torrent state",
+	Long: `Continuously display torrent state using incremental sync/maindata polling.
+On a terminal, a table of torrents is redrawn in place on every refresh.
+Otherwise, torrent changes (added, removed, state_changed, completed) are written as newline-delimited JSON.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rootCtx, cancel := rootEnv.Context()
+		defer cancel()
+
+		ctx, stop := signal.NotifyContext(rootCtx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		flags := cmd.Flags()
+		interval, _ := flags.GetDuration("interval")
+		sortBy, _ := flags.GetString("sort")
+		reverse, _ := flags.GetBool("reverse")
+		filter := torrentFilterFromFlags(cmd)
+
+		if interval <= 0 {
+			return fmt.Errorf("invalid interval: %s", interval)
+		}
+
+		less, ok := torrentSortFuncs[sortBy]
+		if !ok {
+			return fmt.Errorf("invalid sort column: %s", sortBy)
+		}
+
+		if _, ok := torrentStateFilters[filter.Filter]; !ok && filter.Filter != "" {
+			return fmt.Errorf("invalid filter: %s", filter.Filter)
+		}
+
+		cli, err := rootEnv.Client()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		syncer := maindata.New(cli, maindata.WithInterval(interval))
+
+		handle := writeWatchEvents(os.Stdout, filter)
+		if isTerminal(os.Stdout) {
+			handle = func([]maindata.Event) error {
+				torrents := filterTorrents(syncer.Torrents(), filter)
+				sort.SliceStable(torrents, func(i, j int) bool {
+					if reverse {
+						return less(torrents[j], torrents[i])
+					}
+					return less(torrents[i], torrents[j])
+				})
+				return drawWatchScreen(torrents, syncer.ServerState())
+			}
+		}
+
+		return runWatch(ctx, syncer, handle)
+	},
+}
+
+// runWatch follows the syncer until the context is done. Failed polls are retried by the syncer,
+// so only handle can end the watch early.
+func runWatch(ctx context.Context, syncer *maindata.Sync, handle func([]maindata.Event) error) error {
+	err := syncer.Follow(ctx, handle)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// writeWatchEvents returns a handler writing the events matching the filter as newline-delimited JSON.
+func writeWatchEvents(w io.Writer, filter client.ListTorrentsOptions) func([]maindata.Event) error {
+	encoder := json.NewEncoder(w)
+	return func(events []maindata.Event) error {
+		for _, event := range events {
+			if !eventMatches(event, filter) {
+				continue
+			}
+			if err := encoder.Encode(event); err != nil {
+				return fmt.Errorf("failed to write event: %w", err)
+			}
+		}
+		return nil
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+func drawWatchScreen(torrents []client.Torrent, state client.ServerState) error {
+	// Move the cursor home and clear the screen before redrawing
+	fmt.Print("\033[H\033[2J")
+	fmt.Printf("%s  %d torrents  ↓ %s  ↑ %s  %s\n\n",
+		time.Now().Format("15:04:05"),
+		len(torrents),
+		units.FormatRate(state.DlInfoSpeed),
+		units.FormatRate(state.UpInfoSpeed),
+		state.ConnectionStatus,
+	)
+
+	w := newTable()
+	_, _ = fmt.Fprintln(w, "NAME\tSTATE\tPROGRESS\tDOWN\tUP\tETA\tRATIO")
+	for _, t := range torrents {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%.2f\n",
+			truncate(t.Name, 60),
+			t.State,
+			units.FormatPercent(t.Progress),
+			units.FormatRate(t.DlSpeed),
+			units.FormatRate(t.UpSpeed),
+			units.FormatETA(t.ETA),
+			t.Ratio,
+		)
+	}
+	return w.Flush()
+}
+
+var torrentSortFuncs = map[string]func(a, b client.Torrent) bool{
+	"name":     func(a, b client.Torrent) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) },
+	"state":    func(a, b client.Torrent) bool { return a.State < b.State },
+	"progress": func(a, b client.Torrent) bool { return a.Progress < b.Progress },
+	"size":     func(a, b client.Torrent) bool { return a.Size < b.Size },
+	"dlspeed":  func(a, b client.Torrent) bool { return a.DlSpeed < b.DlSpeed },
+	"upspeed":  func(a, b client.Torrent) bool { return a.UpSpeed < b.UpSpeed },
+	"eta":      func(a, b client.Torrent) bool { return a.ETA < b.ETA },
+	"ratio":    func(a, b client.Torrent) bool { return a.Ratio < b.Ratio },
+	"added_on": func(a, b client.Torrent) bool { return a.AddedOn < b.AddedOn },
+}
+
+var (
+	downloadingStates = []string{"downloading", "metaDL", "forcedMetaDL", "stalledDL", "checkingDL", "forcedDL", "queuedDL", "allocating"}
+	seedingStates     = []string{"uploading", "stalledUP", "checkingUP", "forcedUP", "queuedUP"}
+	stoppedStates     = []string{"pausedDL", "pausedUP", "stoppedDL", "stoppedUP"}
+	checkingStates    = []string{"checkingDL", "checkingUP", "checkingResumeData"}
+	erroredStates     = []string{"error", "missingFiles"}
+)
+
+func isActive(t client.Torrent) bool {
+	return t.DlSpeed > 0 || t.UpSpeed > 0
+}
+
+// torrentStateFilters mimics the state filters of torrents/info for locally held torrents.
+var torrentStateFilters = map[string]func(t client.Torrent) bool{
+	"all":                 func(t client.Torrent) bool { return true },
+	"downloading":         func(t client.Torrent) bool { return slices.Contains(downloadingStates, t.State) },
+	"seeding":             func(t client.Torrent) bool { return slices.Contains(seedingStates, t.State) },
+	"completed":           func(t client.Torrent) bool { return t.Progress >= 1 },
+	"stopped":             func(t client.Torrent) bool { return slices.Contains(stoppedStates, t.State) },
+	"paused":              func(t client.Torrent) bool { return slices.Contains(stoppedStates, t.State) },
+	"running":             func(t client.Torrent) bool { return !slices.Contains(stoppedStates, t.State) },
+	"resumed":             func(t client.Torrent) bool { return !slices.Contains(stoppedStates, t.State) },
+	"active":              isActive,
+	"inactive":            func(t client.Torrent) bool { return !isActive(t) },
+	"stalled":             func(t client.Torrent) bool { return t.State == "stalledDL" || t.State == "stalledUP" },
+	"stalled_uploading":   func(t client.Torrent) bool { return t.State == "stalledUP" },
+	"stalled_downloading": func(t client.Torrent) bool { return t.State == "stalledDL" },
+	"checking":            func(t client.Torrent) bool { return slices.Contains(checkingStates, t.State) },
+	"moving":              func(t client.Torrent) bool { return t.State == "moving" },
+	"errored":             func(t client.Torrent) bool { return slices.Contains(erroredStates, t.State) },
+}
+
+func filterTorrents(torrents []client.Torrent, opts client.ListTorrentsOptions) []client.Torrent {
+	stateFilter := torrentStateFilters[opts.Filter]
+
+	filtered := torrents[:0:0]
+	for _, t := range torrents {
+		if stateFilter != nil && !stateFilter(t) {
+			continue
+		}
+		if opts.Category != nil && t.Category != *opts.Category {
+			continue
+		}
+		if opts.Tag != nil {
+			tags := t.TagList()
+			if *opts.Tag == "" && len(tags) > 0 || *opts.Tag != "" && !slices.Contains(tags, *opts.Tag) {
+				continue
+			}
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered
+}
+
+// eventMatches reports whether the event's torrent matches the filter before or after the event,
+// so that consumers also learn about torrents leaving the filtered set.
+func eventMatches(event maindata.Event, filter client.ListTorrentsOptions) bool {
+	if event.Torrent == nil {
+		return true
+	}
+
+	candidates := []client.Torrent{*event.Torrent}
+	if event.PrevState != "" {
+		prev := *event.Torrent
+		prev.State = event.PrevState
+		candidates = append(candidates, prev)
+	}
+	return len(filterTorrents(candidates, filter)) > 0
+}
+
+func init() {
+	watchCmd.Flags().Duration("interval", 2*time.Second, "Refresh interval")
+	watchCmd.Flags().String("sort", "name", "Sort column: name, state, progress, size, dlspeed, upspeed, eta, ratio, added_on")
+	watchCmd.Flags().Bool("reverse", false, "Reverse sort order")
+	addTorrentFilterFlags(watchCmd)
+	rootCmd.AddCommand(watchCmd)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/qb/maindata"
+	"github.com/gstos/qbcli/internal/qb/qbtest"
+)
+
+func TestEventMatches(t *testing.T) {
+	downloading := client.ListTorrentsOptions{Filter: "downloading"}
+
+	tests := []struct {
+		name  string
+		event maindata.Event
+		want  bool
+	}{
+		{"entering", maindata.Event{Type: maindata.TorrentStateChanged, PrevState: "metaDL",
+			Torrent: &client.Torrent{State: "downloading"}}, true},
+		{"leaving", maindata.Event{Type: maindata.TorrentStateChanged, PrevState: "downloading",
+			Torrent: &client.Torrent{State: "stalledUP"}}, true},
+		{"outside", maindata.Event{Type: maindata.TorrentStateChanged, PrevState: "uploading",
+			Torrent: &client.Torrent{State: "stalledUP"}}, false},
+		{"added outside", maindata.Event{Type: maindata.TorrentAdded,
+			Torrent: &client.Torrent{State: "stalledUP"}}, false},
+		{"removed inside", maindata.Event{Type: maindata.TorrentRemoved,
+			Torrent: &client.Torrent{State: "downloading"}}, true},
+	}
+
+	for _, tt := range tests {
+		if got := eventMatches(tt.event, downloading); got != tt.want {
+			t.Errorf("%s: eventMatches() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRunWatchContinuesAfterFailedPoll(t *testing.T) {
+	var polls atomic.Int64
+	server := qbtest.NewServer(t)
+	server.HandleFunc("sync/maindata", func(w http.ResponseWriter, r *http.Request) {
+		switch polls.Add(1) {
+		case 1:
+			_, _ = fmt.Fprint(w, `{"rid":1,"full_update":true,"torrents":{"aaa":{"name":"alpha","state":"downloading"}}}`)
+		case 2:
+			// qBittorrent restarting
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+		default:
+			if rid := r.FormValue("rid"); rid != "0" {
+				t.Errorf("poll after a failure asked for rid %s, want a full update", rid)
+			}
+			_, _ = fmt.Fprint(w, `{"rid":1,"full_update":true,"torrents":{"aaa":{"name":"alpha","state":"downloading"},"bbb":{"name":"bravo","state":"metaDL"}}}`)
+		}
+	})
+	syncer := maindata.New(server.Client(t), maindata.WithInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var out bytes.Buffer
+	write := writeWatchEvents(&out, client.ListTorrentsOptions{})
+	err := runWatch(ctx, syncer, func(events []maindata.Event) error {
+		if len(events) > 0 {
+			cancel()
+		}
+		return write(events)
+	})
+	if err != nil {
+		t.Fatalf("runWatch() = %v, want nil", err)
+	}
+	if polls.Load() < 3 {
+		t.Fatalf("watch stopped after %d polls", polls.Load())
+	}
+
+	var event maindata.Event
+	if err := json.Unmarshal(out.Bytes(), &event); err != nil {
+		t.Fatalf("decoding output %q: %v", out.String(), err)
+	}
+	if event.Type != maindata.TorrentAdded || event.Hash != "bbb" {
+		t.Errorf("got event %s for %s, want added for bbb", event.Type, event.Hash)
+	}
+}
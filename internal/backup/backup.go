@@ -0,0 +1,245 @@
+// Package backup stores .torrent files together with a JSON manifest of their metadata,
+// either in a directory or in a (optionally gzipped) tar archive.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	ManifestName    = "manifest.json"
+	ManifestVersion = 1
+	TorrentsDir     = "torrents"
+)
+
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Host      string    `json:"host,omitempty"`
+	Torrents  []Entry   `json:"torrents"`
+}
+
+// Entry records a torrent and the metadata needed to add it back. File is the path of the
+// .torrent file inside the backup; it is empty when only the magnet link could be saved.
+// ContentLayout is empty when it could not be told from the torrent's paths.
+type Entry struct {
+	Hash                     string   `json:"hash"`
+	Name                     string   `json:"name"`
+	File                     string   `json:"file,omitempty"`
+	MagnetURI                string   `json:"magnet_uri,omitempty"`
+	SavePath                 string   `json:"save_path"`
+	DownloadPath             string   `json:"download_path,omitempty"`
+	ContentLayout            string   `json:"content_layout,omitempty"`
+	Category                 string   `json:"category,omitempty"`
+	Tags                     []string `json:"tags,omitempty"`
+	AutoTMM                  bool     `json:"auto_tmm"`
+	Stopped                  bool     `json:"stopped"`
+	RatioLimit               float64  `json:"ratio_limit"`
+	SeedingTimeLimit         int64    `json:"seeding_time_limit"`
+	InactiveSeedingTimeLimit int64    `json:"inactive_seeding_time_limit"`
+	UpLimit                  int64    `json:"up_limit"`
+	DlLimit                  int64    `json:"dl_limit"`
+	AddedOn                  int64    `json:"added_on"`
+}
+
+// TorrentPath returns the path a torrent file is stored at inside the backup.
+func TorrentPath(hash string) string {
+	return path.Join(TorrentsDir, hash+".torrent")
+}
+
+// IsArchive reports whether the destination names a tar archive rather than a directory.
+func IsArchive(name string) bool {
+	return strings.HasSuffix(name, ".tar") || isGzip(name)
+}
+
+func isGzip(name string) bool {
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz")
+}
+
+type Writer interface {
+	WriteFile(name string, content []byte) error
+	Close() error
+}
+
+// Create opens a backup for writing: a tar archive if dest ends with .tar, .tar.gz or .tgz,
+// a directory otherwise.
+func Create(dest string) (Writer, error) {
+	if !IsArchive(dest) {
+		if err := os.MkdirAll(dest, 0o755); err != nil {
+			return nil, fmt.Errorf("creating backup directory: %w", err)
+		}
+		return dirWriter(dest), nil
+	}
+
+	file, err := os.Create(dest)
+	if err != nil {
+		return nil, fmt.Errorf("creating backup archive: %w", err)
+	}
+
+	w := &tarWriter{file: file}
+	if isGzip(dest) {
+		w.gzip = gzip.NewWriter(file)
+		w.tar = tar.NewWriter(w.gzip)
+	} else {
+		w.tar = tar.NewWriter(file)
+	}
+	return w, nil
+}
+
+type dirWriter string
+
+func (dir dirWriter) WriteFile(name string, content []byte) error {
+	target := filepath.Join(string(dir), filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, content, 0o644)
+}
+
+func (dir dirWriter) Close() error {
+	return nil
+}
+
+type tarWriter struct {
+	file *os.File
+	gzip *gzip.Writer
+	tar  *tar.Writer
+}
+
+func (w *tarWriter) WriteFile(name string, content []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}
+	if err := w.tar.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := w.tar.Write(content)
+	return err
+}
+
+func (w *tarWriter) Close() error {
+	errs := []error{w.tar.Close()}
+	if w.gzip != nil {
+		errs = append(errs, w.gzip.Close())
+	}
+	errs = append(errs, w.file.Close())
+	return errors.Join(errs...)
+}
+
+type Reader interface {
+	ReadFile(name string) ([]byte, error)
+	Close() error
+}
+
+// Open opens a backup directory or archive created by Create.
+func Open(src string) (Reader, error) {
+	stat, err := os.Stat(src)
+	if err != nil {
+		return nil, fmt.Errorf("opening backup: %w", err)
+	}
+	if stat.IsDir() {
+		return dirReader(src), nil
+	}
+
+	file, err := os.Open(src)
+	if err != nil {
+		return nil, fmt.Errorf("opening backup: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var r io.Reader = file
+	if isGzip(src) {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("opening backup: %w", err)
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	}
+
+	// Torrent files are small, so the archive is read into memory
+	files := tarReader{}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading backup archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("reading backup archive: %w", err)
+		}
+		files[path.Clean(header.Name)] = content
+	}
+	return files, nil
+}
+
+type dirReader string
+
+func (dir dirReader) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(string(dir), filepath.FromSlash(name)))
+}
+
+func (dir dirReader) Close() error {
+	return nil
+}
+
+type tarReader map[string][]byte
+
+func (files tarReader) ReadFile(name string) ([]byte, error) {
+	content, ok := files[path.Clean(name)]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, os.ErrNotExist)
+	}
+	return content, nil
+}
+
+func (files tarReader) Close() error {
+	return nil
+}
+
+func WriteManifest(w Writer, manifest *Manifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling manifest: %w", err)
+	}
+	if err := w.WriteFile(ManifestName, content); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	return nil
+}
+
+func ReadManifest(r Reader) (*Manifest, error) {
+	content, err := r.ReadFile(ManifestName)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	if manifest.Version > ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", manifest.Version)
+	}
+	return &manifest, nil
+}
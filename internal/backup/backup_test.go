@@ -0,0 +1,56 @@
+package backup
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, name := range []string{"dir", "backup.tar", "backup.tar.gz"} {
+		t.Run(name, func(t *testing.T) {
+			dest := filepath.Join(t.TempDir(), name)
+			content := []byte("d4:infod4:name3:abcee")
+
+			w, err := Create(dest)
+			if err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			manifest := &Manifest{
+				Version:  ManifestVersion,
+				Torrents: []Entry{{Hash: "aaa", Name: "abc", File: TorrentPath("aaa"), Tags: []string{"iso"}}},
+			}
+			if err := w.WriteFile(TorrentPath("aaa"), content); err != nil {
+				t.Fatalf("write torrent: %v", err)
+			}
+			if err := WriteManifest(w, manifest); err != nil {
+				t.Fatalf("write manifest: %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("close writer: %v", err)
+			}
+
+			r, err := Open(dest)
+			if err != nil {
+				t.Fatalf("open: %v", err)
+			}
+			defer func() { _ = r.Close() }()
+
+			got, err := ReadManifest(r)
+			if err != nil {
+				t.Fatalf("read manifest: %v", err)
+			}
+			if len(got.Torrents) != 1 || got.Torrents[0].Name != "abc" || got.Torrents[0].Tags[0] != "iso" {
+				t.Errorf("got manifest %+v", got)
+			}
+
+			torrent, err := r.ReadFile(got.Torrents[0].File)
+			if err != nil {
+				t.Fatalf("read torrent: %v", err)
+			}
+			if !bytes.Equal(torrent, content) {
+				t.Errorf("got torrent %q, want %q", torrent, content)
+			}
+		})
+	}
+}
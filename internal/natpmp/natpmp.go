@@ -0,0 +1,220 @@
+// Package natpmp implements the client side of NAT-PMP (RFC 6886): discovering the external
+// address of a gateway and requesting port mappings, as used by ProtonVPN port forwarding.
+package natpmp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultPort = 5351
+
+	version           = 0
+	opExternalAddress = 0
+	responseBit       = 128
+
+	defaultInitialTimeout = 250 * time.Millisecond
+	defaultRetries        = 9
+)
+
+type Protocol byte
+
+const (
+	UDP Protocol = 1
+	TCP Protocol = 2
+)
+
+func (p Protocol) String() string {
+	switch p {
+	case UDP:
+		return "udp"
+	case TCP:
+		return "tcp"
+	default:
+		return "protocol(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// ResultError is a non-zero result code returned by the gateway.
+type ResultError uint16
+
+func (e ResultError) Error() string {
+	switch e {
+	case 1:
+		return "natpmp: unsupported version"
+	case 2:
+		return "natpmp: not authorized or refused"
+	case 3:
+		return "natpmp: network failure"
+	case 4:
+		return "natpmp: out of resources"
+	case 5:
+		return "natpmp: unsupported opcode"
+	default:
+		return fmt.Sprintf("natpmp: result code %d", uint16(e))
+	}
+}
+
+var ErrNoResponse = errors.New("natpmp: no response from gateway")
+
+type Mapping struct {
+	Protocol     Protocol
+	InternalPort int
+	ExternalPort int
+	Lifetime     time.Duration
+	Epoch        uint32
+}
+
+// Client talks to a single NAT-PMP gateway. Requests are retransmitted with a doubling timeout,
+// starting at 250ms, as recommended by the RFC.
+type Client struct {
+	gateway        string
+	initialTimeout time.Duration
+	retries        int
+}
+
+type Option func(*Client)
+
+// New returns a client for a gateway given as "host" or "host:port".
+func New(gateway string, opts ...Option) *Client {
+	if _, _, err := net.SplitHostPort(gateway); err != nil {
+		gateway = net.JoinHostPort(gateway, strconv.Itoa(DefaultPort))
+	}
+
+	c := &Client{
+		gateway:        gateway,
+		initialTimeout: defaultInitialTimeout,
+		retries:        defaultRetries,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func WithRetries(retries int, initialTimeout time.Duration) Option {
+	return func(c *Client) {
+		if retries > 0 {
+			c.retries = retries
+		}
+		if initialTimeout > 0 {
+			c.initialTimeout = initialTimeout
+		}
+	}
+}
+
+func (c *Client) Gateway() string {
+	return c.gateway
+}
+
+func (c *Client) ExternalAddress(ctx context.Context) (netip.Addr, error) {
+	resp, err := c.call(ctx, []byte{version, opExternalAddress}, 12)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return netip.AddrFrom4([4]byte(resp[8:12])), nil
+}
+
+// AddPortMapping requests a mapping of internalPort, suggesting externalPort (0 for any).
+// The gateway may grant a different external port and lifetime.
+func (c *Client) AddPortMapping(ctx context.Context, protocol Protocol, internalPort int, externalPort int, lifetime time.Duration) (Mapping, error) {
+	if internalPort <= 0 || internalPort > 65535 || externalPort < 0 || externalPort > 65535 {
+		return Mapping{}, fmt.Errorf("natpmp: invalid ports %d:%d", internalPort, externalPort)
+	}
+	return c.mapping(ctx, protocol, internalPort, externalPort, lifetime)
+}
+
+// DeletePortMapping removes the mapping of internalPort.
+func (c *Client) DeletePortMapping(ctx context.Context, protocol Protocol, internalPort int) error {
+	_, err := c.mapping(ctx, protocol, internalPort, 0, 0)
+	return err
+}
+
+func (c *Client) mapping(ctx context.Context, protocol Protocol, internalPort int, externalPort int, lifetime time.Duration) (Mapping, error) {
+	req := make([]byte, 12)
+	req[0] = version
+	req[1] = byte(protocol)
+	binary.BigEndian.PutUint16(req[4:6], uint16(internalPort))
+	binary.BigEndian.PutUint16(req[6:8], uint16(externalPort))
+	binary.BigEndian.PutUint32(req[8:12], uint32(lifetime/time.Second))
+
+	resp, err := c.call(ctx, req, 16)
+	if err != nil {
+		return Mapping{}, err
+	}
+
+	return Mapping{
+		Protocol:     protocol,
+		Epoch:        binary.BigEndian.Uint32(resp[4:8]),
+		InternalPort: int(binary.BigEndian.Uint16(resp[8:10])),
+		ExternalPort: int(binary.BigEndian.Uint16(resp[10:12])),
+		Lifetime:     time.Duration(binary.BigEndian.Uint32(resp[12:16])) * time.Second,
+	}, nil
+}
+
+// call sends a request and waits for the matching response of at least size bytes,
+// retransmitting on timeout.
+func (c *Client) call(ctx context.Context, req []byte, size int) ([]byte, error) {
+	conn, err := net.Dial("udp", c.gateway)
+	if err != nil {
+		return nil, fmt.Errorf("natpmp: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	// Unblock reads when the context is canceled
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetReadDeadline(time.Now()) })
+	defer stop()
+
+	buf := make([]byte, 64)
+	timeout := c.initialTimeout
+	for attempt := 0; attempt < c.retries; attempt++ {
+		if _, err := conn.Write(req); err != nil {
+			return nil, fmt.Errorf("natpmp: %w", err)
+		}
+
+		deadline := time.Now().Add(timeout)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			deadline = d
+		}
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return nil, fmt.Errorf("natpmp: %w", err)
+		}
+
+		for {
+			n, err := conn.Read(buf)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("natpmp: %w", err)
+			}
+
+			resp := buf[:n]
+			if n < 4 || resp[0] != version || resp[1] != req[1]|responseBit {
+				// Not an answer to this request
+				continue
+			}
+			if result := binary.BigEndian.Uint16(resp[2:4]); result != 0 {
+				return nil, ResultError(result)
+			}
+			if n < size {
+				return nil, fmt.Errorf("natpmp: short response (%d bytes)", n)
+			}
+			return resp, nil
+		}
+
+		timeout *= 2
+	}
+	return nil, ErrNoResponse
+}
@@ -0,0 +1,65 @@
+package natpmp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gstos/qbcli/internal/natpmp/natpmptest"
+)
+
+func TestExternalAddress(t *testing.T) {
+	g := natpmptest.NewGateway(t)
+	c := New(g.Addr(), WithRetries(3, 20*time.Millisecond))
+
+	addr, err := c.ExternalAddress(context.Background())
+	if err != nil {
+		t.Fatalf("ExternalAddress: %v", err)
+	}
+	if addr.String() != "203.0.113.7" {
+		t.Errorf("got address %s, want 203.0.113.7", addr)
+	}
+}
+
+func TestAddPortMappingRetransmits(t *testing.T) {
+	g := natpmptest.NewGateway(t)
+	g.Drop.Store(2)
+	c := New(g.Addr(), WithRetries(5, 20*time.Millisecond))
+
+	for _, protocol := range []Protocol{UDP, TCP} {
+		mapping, err := c.AddPortMapping(context.Background(), protocol, 1, 0, 10*time.Minute)
+		if err != nil {
+			t.Fatalf("AddPortMapping(%s): %v", protocol, err)
+		}
+		if mapping.ExternalPort != 40000 || mapping.InternalPort != 1 || mapping.Lifetime != time.Minute {
+			t.Errorf("got mapping %+v", mapping)
+		}
+	}
+
+	if got := len(g.Requests()); got != 4 {
+		t.Errorf("gateway received %d requests, want 4 (2 dropped)", got)
+	}
+}
+
+func TestResultError(t *testing.T) {
+	g := natpmptest.NewGateway(t)
+	g.Result.Store(2)
+	c := New(g.Addr(), WithRetries(3, 20*time.Millisecond))
+
+	_, err := c.AddPortMapping(context.Background(), TCP, 1, 0, time.Minute)
+	var resultErr ResultError
+	if !errors.As(err, &resultErr) || resultErr != 2 {
+		t.Errorf("got error %v, want result code 2", err)
+	}
+}
+
+func TestNoResponse(t *testing.T) {
+	g := natpmptest.NewGateway(t)
+	g.Drop.Store(100)
+	c := New(g.Addr(), WithRetries(2, 10*time.Millisecond))
+
+	if _, err := c.ExternalAddress(context.Background()); !errors.Is(err, ErrNoResponse) {
+		t.Errorf("got error %v, want ErrNoResponse", err)
+	}
+}
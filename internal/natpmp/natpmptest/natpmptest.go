@@ -0,0 +1,108 @@
+// Package natpmptest runs a fake NAT-PMP gateway for tests.
+package natpmptest
+
+import (
+	"encoding/binary"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// Request is a request received by the gateway. Op is 0 for the external address, 1 for a
+// UDP and 2 for a TCP mapping; a mapping with a zero lifetime is a deletion.
+type Request struct {
+	Op           byte
+	InternalPort int
+	ExternalPort int
+	Lifetime     uint32
+}
+
+// Gateway answers NAT-PMP requests on a local UDP socket. It grants mappings on Port
+// (40000 unless set) for at most MaxLifetime seconds (60 unless set), and answers with
+// Result as the result code. The next Drop requests are left unanswered.
+type Gateway struct {
+	Drop        atomic.Int32
+	Result      atomic.Uint32
+	Port        atomic.Uint32
+	MaxLifetime atomic.Uint32
+
+	conn     *net.UDPConn
+	mu       sync.Mutex
+	requests []Request
+}
+
+// NewGateway starts a gateway that is closed when the test ends.
+func NewGateway(t testing.TB) *Gateway {
+	t.Helper()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	g := &Gateway{conn: conn}
+	g.Port.Store(40000)
+	g.MaxLifetime.Store(60)
+	go g.serve()
+	return g
+}
+
+// Addr returns the "host:port" address of the gateway.
+func (g *Gateway) Addr() string {
+	return g.conn.LocalAddr().String()
+}
+
+// Requests returns the requests received so far, including the dropped ones.
+func (g *Gateway) Requests() []Request {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Request(nil), g.requests...)
+}
+
+func (g *Gateway) serve() {
+	buf := make([]byte, 64)
+	for {
+		n, addr, err := g.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		if n < 2 {
+			continue
+		}
+
+		op := buf[1]
+		req := Request{Op: op}
+		if op != 0 && n >= 12 {
+			req.InternalPort = int(binary.BigEndian.Uint16(buf[4:6]))
+			req.ExternalPort = int(binary.BigEndian.Uint16(buf[6:8]))
+			req.Lifetime = binary.BigEndian.Uint32(buf[8:12])
+		}
+		g.mu.Lock()
+		g.requests = append(g.requests, req)
+		g.mu.Unlock()
+
+		if g.Drop.Add(-1) >= 0 {
+			continue
+		}
+
+		resp := make([]byte, 16)
+		resp[1] = op | 128
+		binary.BigEndian.PutUint16(resp[2:4], uint16(g.Result.Load()))
+		binary.BigEndian.PutUint32(resp[4:8], 1234)
+
+		switch op {
+		case 0:
+			copy(resp[8:12], []byte{203, 0, 113, 7})
+			resp = resp[:12]
+		default:
+			binary.BigEndian.PutUint16(resp[8:10], uint16(req.InternalPort))
+			if req.Lifetime > 0 {
+				binary.BigEndian.PutUint16(resp[10:12], uint16(g.Port.Load()))
+			}
+			binary.BigEndian.PutUint32(resp[12:16], min(req.Lifetime, g.MaxLifetime.Load()))
+		}
+		_, _ = g.conn.WriteToUDP(resp, addr)
+	}
+}
@@ -0,0 +1,136 @@
+package portsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// FileSource reads the port from a file such as gluetun's /tmp/gluetun/forwarded_port.
+// When the file lists several ports (one per line or comma separated), the first one is used.
+type FileSource struct {
+	Path string
+}
+
+func NewFileSource(path string) *FileSource {
+	return &FileSource{Path: path}
+}
+
+func (f *FileSource) Name() string {
+	return "file " + f.Path
+}
+
+func (f *FileSource) Port(ctx context.Context) (int, error) {
+	content, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, ErrNoPort
+	}
+	if err != nil {
+		return 0, err
+	}
+	return ParsePort(string(content))
+}
+
+// ParsePort returns the first port of a whitespace or comma separated list.
+func ParsePort(s string) (int, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+	if len(fields) == 0 {
+		return 0, ErrNoPort
+	}
+
+	port, err := strconv.Atoi(fields[0])
+	if err != nil || port <= 0 || port > 65535 {
+		return 0, fmt.Errorf("invalid port: %q", fields[0])
+	}
+	return port, nil
+}
+
+// watchRetryInterval is how often a missing directory is checked for again.
+var watchRetryInterval = 5 * time.Second
+
+// Watch watches the file's directory, since the file may not exist yet or be replaced
+// rather than written in place. The directory may not exist yet either (e.g. when started
+// before gluetun): it is then looked for again every few seconds, the periodic reconcile
+// covering the meantime, and likewise if it is removed later.
+func (f *FileSource) Watch(ctx context.Context) (<-chan struct{}, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Clean(filepath.Dir(f.Path))
+	name := filepath.Clean(f.Path)
+	changes := make(chan struct{}, 1)
+
+	// Coalesce bursts of events into a single pending notification
+	notify := func() {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+
+	go func() {
+		defer close(changes)
+		defer func() { _ = watcher.Close() }()
+
+		for {
+			for watcher.Add(dir) != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(watchRetryInterval):
+				}
+			}
+			// The file may have been written while the directory was not watched
+			notify()
+
+			if !watchDir(ctx, watcher, dir, name, notify) {
+				return
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// watchDir forwards the file's events until the directory is removed, and reports whether
+// watching should go on.
+func watchDir(ctx context.Context, watcher *fsnotify.Watcher, dir string, name string, notify func()) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return false
+			}
+			switch filepath.Clean(event.Name) {
+			case dir:
+				if event.Has(fsnotify.Remove | fsnotify.Rename) {
+					_ = watcher.Remove(dir)
+					notify()
+					return true
+				}
+			case name:
+				if event.Has(fsnotify.Write | fsnotify.Create | fsnotify.Rename | fsnotify.Remove) {
+					notify()
+				}
+			}
+		case _, ok := <-watcher.Errors:
+			// Missed events are caught up by the periodic reconcile
+			if !ok {
+				return false
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package portsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Gluetun control server routes reporting the forwarded port. The first one is used by recent
+// releases; the second one is the older, OpenVPN only, route.
+var gluetunRoutes = []string{"/v1/portforward", "/v1/openvpn/portforwarded"}
+
+// GluetunSource polls gluetun's HTTP control server for the forwarded port.
+// It authenticates with an API key or with basic auth when they are set.
+type GluetunSource struct {
+	BaseURL  string
+	APIKey   string
+	Username string
+	Password string
+	Client   *http.Client
+
+	mu    sync.Mutex
+	route string
+}
+
+func NewGluetunSource(baseURL string) *GluetunSource {
+	return &GluetunSource{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (g *GluetunSource) Name() string {
+	return "gluetun " + g.BaseURL
+}
+
+func (g *GluetunSource) Port(ctx context.Context) (int, error) {
+	g.mu.Lock()
+	route := g.route
+	g.mu.Unlock()
+
+	if route != "" {
+		port, found, err := g.fetch(ctx, route)
+		if found {
+			return port, err
+		}
+		// The route went away (e.g. gluetun was upgraded), look for it again
+		g.mu.Lock()
+		g.route = ""
+		g.mu.Unlock()
+	}
+
+	for _, route := range gluetunRoutes {
+		port, found, err := g.fetch(ctx, route)
+		if !found {
+			continue
+		}
+		if err == nil {
+			g.mu.Lock()
+			g.route = route
+			g.mu.Unlock()
+		}
+		return port, err
+	}
+	return 0, fmt.Errorf("no port forwarding route found on %s", g.BaseURL)
+}
+
+// fetch queries a route; found is false when the route does not exist on this gluetun release.
+func (g *GluetunSource) fetch(ctx context.Context, route string) (port int, found bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.BaseURL+route, nil)
+	if err != nil {
+		return 0, true, err
+	}
+	if g.APIKey != "" {
+		req.Header.Set("X-API-Key", g.APIKey)
+	}
+	if g.Username != "" || g.Password != "" {
+		req.SetBasicAuth(g.Username, g.Password)
+	}
+
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return 0, true, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return 0, true, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return 0, false, nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return 0, true, fmt.Errorf("gluetun control server rejected credentials: %s", resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return 0, true, fmt.Errorf("gluetun control server: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var data struct {
+		Port  int   `json:"port"`
+		Ports []int `json:"ports"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return 0, true, fmt.Errorf("parsing gluetun response: %w", err)
+	}
+
+	port = data.Port
+	if port == 0 && len(data.Ports) > 0 {
+		port = data.Ports[0]
+	}
+	if port <= 0 || port > 65535 {
+		// gluetun reports 0 until a port has been forwarded
+		return 0, true, ErrNoPort
+	}
+	return port, true, nil
+}
@@ -0,0 +1,113 @@
+package portsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newGluetunServer(t *testing.T, route string, port *atomic.Int64) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != route {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"port":%d}`, port.Load())
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGluetunSource(t *testing.T) {
+	for _, route := range gluetunRoutes {
+		t.Run(route, func(t *testing.T) {
+			var port atomic.Int64
+			server := newGluetunServer(t, route, &port)
+			ctx := context.Background()
+
+			source := NewGluetunSource(server.URL + "/")
+			source.APIKey = "secret"
+
+			if _, err := source.Port(ctx); !errors.Is(err, ErrNoPort) {
+				t.Errorf("Port() before forwarding: got %v, want ErrNoPort", err)
+			}
+
+			port.Store(51413)
+			got, err := source.Port(ctx)
+			if err != nil || got != 51413 {
+				t.Errorf("Port() = %d, %v; want 51413", got, err)
+			}
+
+			source.APIKey = "wrong"
+			if _, err := source.Port(ctx); err == nil {
+				t.Error("Port() with a wrong API key: expected error")
+			}
+		})
+	}
+}
+
+func TestReconcileFromGluetun(t *testing.T) {
+	var port atomic.Int64
+	port.Store(40000)
+	server := newGluetunServer(t, "/v1/portforward", &port)
+
+	cli, prefs := newTestClient(t, 6881)
+
+	source := NewGluetunSource(server.URL)
+	source.APIKey = "secret"
+	syncer := New(cli, source)
+
+	changed, err := syncer.Reconcile(context.Background())
+	if err != nil || !changed {
+		t.Fatalf("Reconcile() = %v, %v; want a change", changed, err)
+	}
+	if prefs.Int("listen_port") != 40000 {
+		t.Errorf("listening port is %d, want 40000", prefs.Int("listen_port"))
+	}
+}
+
+func TestGluetunSourceRouteGone(t *testing.T) {
+	var route atomic.Pointer[string]
+	route.Store(&gluetunRoutes[1])
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if current := route.Load(); current == nil || r.URL.Path != *current {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"port":40000}`)
+	}))
+	t.Cleanup(server.Close)
+
+	cli, prefs := newTestClient(t, 6881)
+	syncer := New(cli, NewGluetunSource(server.URL))
+	ctx := context.Background()
+
+	if _, err := syncer.Reconcile(ctx); err != nil {
+		t.Fatalf("Reconcile() = %v", err)
+	}
+
+	// The cached route is not found anymore, e.g. behind a proxy
+	route.Store(nil)
+	if changed, err := syncer.Reconcile(ctx); err == nil || changed {
+		t.Errorf("Reconcile() without a route = %v, %v; want an error", changed, err)
+	}
+	if prefs.Int("listen_port") != 40000 {
+		t.Errorf("listening port is %d, want 40000", prefs.Int("listen_port"))
+	}
+
+	// The route moved, e.g. after an upgrade
+	route.Store(&gluetunRoutes[0])
+	if _, err := syncer.Reconcile(ctx); err != nil {
+		t.Errorf("Reconcile() after the route moved = %v", err)
+	}
+}
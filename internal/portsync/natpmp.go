@@ -0,0 +1,154 @@
+package portsync
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gstos/qbcli/internal/natpmp"
+)
+
+// NATPMPSource keeps TCP and UDP mappings open on a NAT-PMP gateway (e.g. ProtonVPN's 10.2.0.1)
+// and reports the public port. Mappings are renewed at half their lifetime while watched,
+// and deleted when the watch ends. The port is forgotten once renewals fail past the
+// granted lifetime.
+type NATPMPSource struct {
+	client       *natpmp.Client
+	internalPort int
+	lifetime     time.Duration
+	Log          *slog.Logger
+
+	mu      sync.Mutex
+	port    int
+	expires time.Time
+}
+
+func NewNATPMPSource(client *natpmp.Client, internalPort int, lifetime time.Duration, logger *slog.Logger) *NATPMPSource {
+	return &NATPMPSource{
+		client:       client,
+		internalPort: internalPort,
+		lifetime:     lifetime,
+		Log:          logger,
+	}
+}
+
+func (n *NATPMPSource) Name() string {
+	return "natpmp " + n.client.Gateway()
+}
+
+func (n *NATPMPSource) Port(ctx context.Context) (int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.port == 0 {
+		return 0, ErrNoPort
+	}
+	return n.port, nil
+}
+
+func (n *NATPMPSource) Watch(ctx context.Context) (<-chan struct{}, error) {
+	changes := make(chan struct{}, 1)
+
+	go func() {
+		defer close(changes)
+		defer n.deleteMappings(ctx)
+
+		for {
+			next := n.lifetime / 2
+			port, lifetime, err := n.renew(ctx)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				n.Log.Warn("port mapping failed", "gateway", n.client.Gateway(), "error", err)
+				next = min(next, 10*time.Second)
+				if n.expire() {
+					n.Log.Warn("port mapping expired", "gateway", n.client.Gateway())
+					select {
+					case changes <- struct{}{}:
+					default:
+					}
+				}
+			default:
+				if lifetime > 0 {
+					next = lifetime / 2
+				} else {
+					lifetime = n.lifetime
+				}
+				if n.setPort(port, time.Now().Add(lifetime)) {
+					select {
+					case changes <- struct{}{}:
+					default:
+					}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(next):
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// renew requests the UDP and TCP mappings, asking for the current public port so that it is kept.
+func (n *NATPMPSource) renew(ctx context.Context) (int, time.Duration, error) {
+	n.mu.Lock()
+	suggested := n.port
+	n.mu.Unlock()
+
+	udp, err := n.client.AddPortMapping(ctx, natpmp.UDP, n.internalPort, suggested, n.lifetime)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tcp, err := n.client.AddPortMapping(ctx, natpmp.TCP, n.internalPort, udp.ExternalPort, n.lifetime)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if tcp.ExternalPort != udp.ExternalPort {
+		n.Log.Warn("gateway mapped different TCP and UDP ports, using TCP", "tcp", tcp.ExternalPort, "udp", udp.ExternalPort)
+	}
+
+	n.Log.Debug("port mapping renewed", "port", tcp.ExternalPort, "lifetime", tcp.Lifetime)
+	return tcp.ExternalPort, min(udp.Lifetime, tcp.Lifetime), nil
+}
+
+func (n *NATPMPSource) setPort(port int, expires time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	changed := n.port != port
+	n.port = port
+	n.expires = expires
+	return changed
+}
+
+// expire forgets the port once its mappings outlived the granted lifetime, and reports
+// whether it did.
+func (n *NATPMPSource) expire() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.port == 0 || time.Now().Before(n.expires) {
+		return false
+	}
+	n.port = 0
+	return true
+}
+
+func (n *NATPMPSource) deleteMappings(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 2*time.Second)
+	defer cancel()
+
+	for _, protocol := range []natpmp.Protocol{natpmp.UDP, natpmp.TCP} {
+		if err := n.client.DeletePortMapping(ctx, protocol, n.internalPort); err != nil {
+			n.Log.Debug("deleting port mapping failed", "protocol", protocol, "error", err)
+		}
+	}
+}
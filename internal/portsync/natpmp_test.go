@@ -0,0 +1,118 @@
+package portsync
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/gstos/qbcli/internal/natpmp"
+	"github.com/gstos/qbcli/internal/natpmp/natpmptest"
+)
+
+// waitFor polls cond until it holds or the timeout expires.
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cond()
+}
+
+func newTestNATPMPSource(g *natpmptest.Gateway, lifetime time.Duration) *NATPMPSource {
+	cli := natpmp.New(g.Addr(), natpmp.WithRetries(1, 50*time.Millisecond))
+	return NewNATPMPSource(cli, 6881, lifetime, slog.New(slog.DiscardHandler))
+}
+
+func TestNATPMPSourceRenewsAndKeepsPort(t *testing.T) {
+	g := natpmptest.NewGateway(t)
+	g.MaxLifetime.Store(1)
+	source := newTestNATPMPSource(g, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if _, err := source.Watch(ctx); err != nil {
+		t.Fatalf("Watch() = %v", err)
+	}
+
+	if !waitFor(t, time.Second, func() bool { port, _ := source.Port(ctx); return port == 40000 }) {
+		t.Fatal("the mapped port was not reported")
+	}
+
+	// A second UDP and TCP pair, half the granted second later rather than half the requested hour
+	if !waitFor(t, 2*time.Second, func() bool { return len(g.Requests()) >= 4 }) {
+		t.Fatalf("mappings were not renewed, requests: %+v", g.Requests())
+	}
+
+	requests := g.Requests()
+	want := []natpmptest.Request{
+		{Op: byte(natpmp.UDP), InternalPort: 6881, ExternalPort: 0, Lifetime: 3600},
+		{Op: byte(natpmp.TCP), InternalPort: 6881, ExternalPort: 40000, Lifetime: 3600},
+		{Op: byte(natpmp.UDP), InternalPort: 6881, ExternalPort: 40000, Lifetime: 3600},
+		{Op: byte(natpmp.TCP), InternalPort: 6881, ExternalPort: 40000, Lifetime: 3600},
+	}
+	for i, req := range want {
+		if requests[i] != req {
+			t.Errorf("request %d = %+v, want %+v", i, requests[i], req)
+		}
+	}
+}
+
+func TestNATPMPSourceDeletesMappingsOnShutdown(t *testing.T) {
+	g := natpmptest.NewGateway(t)
+	source := newTestNATPMPSource(g, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := source.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() = %v", err)
+	}
+	<-changes
+	cancel()
+	for range changes {
+	}
+
+	requests := g.Requests()
+	if len(requests) < 2 {
+		t.Fatalf("requests = %+v, want the mappings deleted", requests)
+	}
+	deletes := requests[len(requests)-2:]
+	for i, protocol := range []natpmp.Protocol{natpmp.UDP, natpmp.TCP} {
+		want := natpmptest.Request{Op: byte(protocol), InternalPort: 6881}
+		if deletes[i] != want {
+			t.Errorf("request %+v, want %s mapping deleted", deletes[i], protocol)
+		}
+	}
+}
+
+func TestNATPMPSourceForgetsExpiredPort(t *testing.T) {
+	g := natpmptest.NewGateway(t)
+	g.MaxLifetime.Store(1)
+	source := newTestNATPMPSource(g, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, err := source.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() = %v", err)
+	}
+	<-changes
+
+	// The gateway goes away: renewals fail until the granted second has passed
+	g.Drop.Store(1 << 20)
+
+	select {
+	case <-changes:
+	case <-time.After(3 * time.Second):
+		t.Fatal("no change was reported when the mapping expired")
+	}
+	if _, err := source.Port(ctx); !errors.Is(err, ErrNoPort) {
+		t.Errorf("Port() = %v, want ErrNoPort", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Package portsync keeps qBittorrent's listening port in line with a forwarded port reported
+// by a source, such as the file written by gluetun.
+package portsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+)
+
+// Source reports the currently forwarded port.
+type Source interface {
+	Name() string
+	Port(ctx context.Context) (int, error)
+}
+
+// Watcher is implemented by sources that can tell when the port may have changed, so that
+// the port is re-applied right away instead of at the next periodic reconcile.
+type Watcher interface {
+	Watch(ctx context.Context) (<-chan struct{}, error)
+}
+
+// Syncer applies the port of a source to qBittorrent whenever the source changes and
+// periodically, so that drifts (e.g. after a qBittorrent restart) are corrected.
+type Syncer struct {
+	cli      *client.Client
+	source   Source
+	interval time.Duration
+	portOpts client.ListeningPortOptions
+	Log      *slog.Logger
+}
+
+type Option func(*Syncer)
+
+const defaultInterval = time.Minute
+
+func New(cli *client.Client, source Source, opts ...Option) *Syncer {
+	s := &Syncer{
+		cli:      cli,
+		source:   source,
+		interval: defaultInterval,
+		Log:      cli.Log,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func WithInterval(interval time.Duration) Option {
+	return func(s *Syncer) {
+		if interval > 0 {
+			s.interval = interval
+		}
+	}
+}
+
+// WithListeningPortOptions makes each reconcile also turn off random_port and upnp, as set in opts.
+func WithListeningPortOptions(opts client.ListeningPortOptions) Option {
+	return func(s *Syncer) {
+		s.portOpts = opts
+	}
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Syncer) {
+		s.Log = logger
+	}
+}
+
+// Run reconciles until the context is done. Failed reconciles are logged and retried
+// on the next change or tick, since both qBittorrent and the source may come and go.
+func (s *Syncer) Run(ctx context.Context) error {
+	var changes <-chan struct{}
+	if watcher, ok := s.source.(Watcher); ok {
+		ch, err := watcher.Watch(ctx)
+		if err != nil {
+			return fmt.Errorf("watching %s: %w", s.source.Name(), err)
+		}
+		changes = ch
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := s.Reconcile(ctx)
+		switch {
+		case err == nil || ctx.Err() != nil:
+		case errors.Is(err, ErrNoPort):
+			s.Log.Info("waiting for forwarded port", "source", s.source.Name())
+		default:
+			s.Log.Warn("port reconcile failed", "source", s.source.Name(), "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			// Let the watcher release its resources (e.g. port mappings) before returning;
+			// changes is nil, and would block forever, for sources that are not watched
+			if changes != nil {
+				for range changes {
+				}
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		case _, ok := <-changes:
+			if !ok {
+				// Watchers close changes once the context is done, which may win the select
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return fmt.Errorf("watching %s: watcher stopped", s.source.Name())
+			}
+			s.Log.Debug("port source changed", "source", s.source.Name())
+		}
+	}
+}
+
+// ErrNoPort is returned by sources that do not know the forwarded port yet.
+var ErrNoPort = errors.New("no forwarded port available")
+
+// Reconcile sets qBittorrent's listening port to the source port if they differ and
+// reports whether a change was made.
+func (s *Syncer) Reconcile(ctx context.Context) (bool, error) {
+	port, err := s.source.Port(ctx)
+	if err != nil {
+		return false, fmt.Errorf("reading port from %s: %w", s.source.Name(), err)
+	}
+	if port <= 0 || port > 65535 {
+		return false, fmt.Errorf("reading port from %s: invalid port %d: %w", s.source.Name(), port, ErrNoPort)
+	}
+
+	changed, err := s.cli.EnsureListeningPort(ctx, port, s.portOpts)
+	if err != nil {
+		return changed, fmt.Errorf("setting listening port: %w", err)
+	}
+
+	if changed {
+		s.Log.Info("listening port updated", "source", s.source.Name(), "port", port)
+	} else {
+		s.Log.Debug("listening port in sync", "port", port)
+	}
+	return changed, nil
+}
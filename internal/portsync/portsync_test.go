@@ -0,0 +1,250 @@
+package portsync
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/qb/qbtest"
+)
+
+// newTestClient returns a client of a fake qBittorrent listening on port, and its preferences.
+func newTestClient(t *testing.T, port int) (*client.Client, *qbtest.Preferences) {
+	t.Helper()
+
+	server := qbtest.NewServer(t)
+	prefs := server.Preferences(map[string]any{"listen_port": port, "random_port": false, "upnp": false})
+	return server.Client(t), prefs
+}
+
+func TestParsePort(t *testing.T) {
+	tests := map[string]int{
+		"51413\n":       51413,
+		"40000,40001":   40000,
+		" 1234 \n5678 ": 1234,
+	}
+	for in, want := range tests {
+		got, err := ParsePort(in)
+		if err != nil || got != want {
+			t.Errorf("ParsePort(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"", "abc", "70000", "0"} {
+		if _, err := ParsePort(in); err == nil {
+			t.Errorf("ParsePort(%q) expected error", in)
+		}
+	}
+}
+
+func TestRunWaitsForDirectory(t *testing.T) {
+	watchRetryInterval = 20 * time.Millisecond
+	t.Cleanup(func() { watchRetryInterval = 5 * time.Second })
+
+	cli, prefs := newTestClient(t, 6881)
+
+	// The directory is created after the syncer started, as when gluetun starts later
+	dir := filepath.Join(t.TempDir(), "gluetun")
+	syncer := New(cli, NewFileSource(filepath.Join(dir, "forwarded_port")), WithInterval(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- syncer.Run(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "forwarded_port"), []byte("51413\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for prefs.Int("listen_port") != 51413 {
+		select {
+		case err := <-done:
+			t.Fatalf("Run() returned early: %v", err)
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("listening port is %d, want 51413", prefs.Int("listen_port"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRunFollowsFile(t *testing.T) {
+	cli, prefs := newTestClient(t, 6881)
+
+	path := filepath.Join(t.TempDir(), "forwarded_port")
+	syncer := New(cli, NewFileSource(path), WithInterval(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = syncer.Run(ctx) }()
+
+	waitForPort := func(want int) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for prefs.Int("listen_port") != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("listening port is %d, want %d", prefs.Int("listen_port"), want)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// The file is created after the syncer started
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("51413\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitForPort(51413)
+
+	if err := os.WriteFile(path, []byte("40000\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitForPort(40000)
+
+	// A reconcile with nothing to change must not write
+	writes := prefs.Writes()
+	if changed, err := syncer.Reconcile(ctx); err != nil || changed {
+		t.Errorf("Reconcile() = %v, %v; want no change", changed, err)
+	}
+	if prefs.Writes() != writes {
+		t.Error("Reconcile wrote preferences although the port was in sync")
+	}
+}
+
+// staticSource always reports the same port.
+type staticSource int
+
+func (s staticSource) Name() string                          { return "static" }
+func (s staticSource) Port(ctx context.Context) (int, error) { return int(s), nil }
+
+func TestReconcileDisablesRandomPortAndUPnP(t *testing.T) {
+	cli, prefs := newTestClient(t, 51413)
+	prefs.Set("random_port", true)
+	prefs.Set("upnp", true)
+
+	opts := client.ListeningPortOptions{DisableRandomPort: true, DisableUPnP: true}
+	syncer := New(cli, staticSource(51413), WithListeningPortOptions(opts))
+
+	if changed, err := syncer.Reconcile(context.Background()); err != nil || !changed {
+		t.Fatalf("Reconcile() = %v, %v; want a change", changed, err)
+	}
+	if prefs.Get("random_port") != false || prefs.Get("upnp") != false {
+		t.Errorf("random_port = %v, upnp = %v; want both off", prefs.Get("random_port"), prefs.Get("upnp"))
+	}
+}
+
+func TestReconcileDetectsIgnoredWrite(t *testing.T) {
+	cli, prefs := newTestClient(t, 6881)
+	prefs.IgnoreWrites(true)
+
+	syncer := New(cli, staticSource(51413))
+	_, err := syncer.Reconcile(context.Background())
+
+	var mismatch client.PreferenceMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Reconcile() error = %v, want PreferenceMismatchError", err)
+	}
+	if mismatch.Key != "listen_port" || mismatch.Got != 6881 {
+		t.Errorf("mismatch = %+v, want listen_port got 6881", mismatch)
+	}
+}
+
+func TestReconcileRejectsPortZero(t *testing.T) {
+	cli, prefs := newTestClient(t, 6881)
+	syncer := New(cli, staticSource(0))
+
+	if _, err := syncer.Reconcile(context.Background()); !errors.Is(err, ErrNoPort) {
+		t.Errorf("Reconcile() = %v, want ErrNoPort", err)
+	}
+	if prefs.Writes() != 0 {
+		t.Errorf("port 0 was written to qBittorrent")
+	}
+}
+
+func TestRunStopsWithoutWatcher(t *testing.T) {
+	cli, _ := newTestClient(t, 6881)
+	syncer := New(cli, staticSource(51413), WithInterval(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- syncer.Run(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Run() = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
+
+// pendingSource has a change pending and blocks in Port until the context is done, as a
+// source does when shutdown interrupts a reconcile. Port returns once the watcher has closed
+// its changes, so that Run finds both the context and the changes ready.
+type pendingSource struct {
+	reading chan struct{}
+	closed  chan struct{}
+}
+
+func (s pendingSource) Name() string { return "pending" }
+
+func (s pendingSource) Port(ctx context.Context) (int, error) {
+	select {
+	case s.reading <- struct{}{}:
+	default:
+	}
+	<-ctx.Done()
+	<-s.closed
+	return 0, ctx.Err()
+}
+
+func (s pendingSource) Watch(ctx context.Context) (<-chan struct{}, error) {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	go func() {
+		<-ctx.Done()
+		close(changes)
+		close(s.closed)
+	}()
+	return changes, nil
+}
+
+func TestRunStopsWithPendingChange(t *testing.T) {
+	cli, _ := newTestClient(t, 6881)
+
+	// select picks among ready cases at random, so repeat to hit the closed changes
+	for i := 0; i < 50; i++ {
+		source := pendingSource{reading: make(chan struct{}, 1), closed: make(chan struct{})}
+		syncer := New(cli, source, WithInterval(time.Hour))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- syncer.Run(ctx) }()
+
+		<-source.reading
+		cancel()
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("Run() = %v, want context.Canceled", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Run did not return after the context was cancelled")
+		}
+	}
+}
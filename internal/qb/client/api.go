@@ -94,20 +94,85 @@ func (cli *Client) SetPreferences(ctx context.Context, prefs map[string]any) err
 	return nil
 }
 
+// ListeningPortOptions turns off the settings that would make qBittorrent pick or advertise
+// another port than the one set.
+type ListeningPortOptions struct {
+	DisableRandomPort bool
+	DisableUPnP       bool
+}
+
+// SetListeningPort sets listen_port unless it already has the requested value, and reads it back
+// to verify it was applied.
 func (cli *Client) SetListeningPort(ctx context.Context, port int) error {
+	_, err := cli.EnsureListeningPort(ctx, port, ListeningPortOptions{})
+	return err
+}
+
+// EnsureListeningPort makes listen_port (and random_port and upnp, as requested by opts) match,
+// writing only the preferences that differ. The written values are read back, and a
+// PreferenceMismatchError is returned if qBittorrent ignored any of them.
+// It reports whether preferences were written.
+func (cli *Client) EnsureListeningPort(ctx context.Context, port int, opts ListeningPortOptions) (bool, error) {
 	if port < 0 || port > 65535 {
 		cli.Log.Error("invalid port", "port", port)
-		return fmt.Errorf("invalid port: %d", port)
+		return false, fmt.Errorf("invalid port: %d", port)
+	}
+
+	prefs, err := cli.GetPreferences(ctx)
+	if err != nil {
+		cli.Log.Error("getting listening port", "error", err)
+		return false, fmt.Errorf("getting listening port: %w", err)
 	}
 
-	err := cli.SetPreferences(ctx, map[string]any{"listen_port": port})
+	current, err := listeningPortFrom(prefs)
 	if err != nil {
+		cli.Log.Error("invalid listening port", "port", prefs["listen_port"])
+		return false, err
+	}
+
+	want := map[string]any{}
+	if current != port {
+		want["listen_port"] = port
+	}
+	if opts.DisableRandomPort && prefs["random_port"] != false {
+		want["random_port"] = false
+	}
+	if opts.DisableUPnP && prefs["upnp"] != false {
+		want["upnp"] = false
+	}
+
+	if len(want) == 0 {
+		cli.Log.Debug("listening port already set", "port", port)
+		return false, nil
+	}
+
+	if err := cli.SetPreferences(ctx, want); err != nil {
 		cli.Log.Error("setting listening port", "port", port, "error", err)
-		return fmt.Errorf("setting preferences: %w", err)
+		return false, fmt.Errorf("setting preferences: %w", err)
 	}
 
-	cli.Log.Info("listening port set", "port", port)
-	return nil
+	prefs, err = cli.GetPreferences(ctx)
+	if err != nil {
+		cli.Log.Error("verifying listening port", "error", err)
+		return true, fmt.Errorf("verifying listening port: %w", err)
+	}
+
+	if got, err := listeningPortFrom(prefs); err != nil {
+		return true, err
+	} else if got != port {
+		cli.Log.Error("listening port not applied", "port", port, "actual", got)
+		return true, PreferenceMismatchError{Key: "listen_port", Want: port, Got: got}
+	}
+
+	for _, key := range []string{"random_port", "upnp"} {
+		if _, ok := want[key]; ok && prefs[key] != false {
+			cli.Log.Error("preference not applied", "key", key, "actual", prefs[key])
+			return true, PreferenceMismatchError{Key: key, Want: false, Got: prefs[key]}
+		}
+	}
+
+	cli.Log.Info("listening port set", "port", port, "previous", current)
+	return true, nil
 }
 
 func (cli *Client) GetListeningPort(ctx context.Context) (int, error) {
@@ -117,20 +182,25 @@ func (cli *Client) GetListeningPort(ctx context.Context) (int, error) {
 		return -1, fmt.Errorf("getting listening port: %w", err)
 	}
 
-	var port int
-	switch value := value.(type) {
+	port, err := listeningPortFrom(map[string]any{"listen_port": value})
+	if err != nil {
+		cli.Log.Error("invalid listening port", "port", value)
+		return -1, err
+	}
+	return port, nil
+}
+
+func listeningPortFrom(prefs map[string]any) (int, error) {
+	switch value := prefs["listen_port"].(type) {
 	case string:
-		port, err = strconv.Atoi(value)
+		port, err := strconv.Atoi(value)
 		if err != nil {
-			cli.Log.Error("invalid listening port", "port", value)
 			return -1, fmt.Errorf("invalid listening port: %v", value)
 		}
 		return port, nil
 	case float64:
-		port = int(value)
-		return port, nil
+		return int(value), nil
 	default:
-		cli.Log.Error("invalid listening port", "port", value)
 		return -1, fmt.Errorf("invalid listening port: %v", value)
 	}
 }
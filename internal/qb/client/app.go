@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func (cli *Client) WebAPIVersion(ctx context.Context) (string, error) {
+	if cli.webAPIVersion != "" {
+		return cli.webAPIVersion, nil
+	}
+
+	body, _, err := cli.Get(ctx, "app/webapiVersion", nil, nil, cli.SessionAuth)
+	if err != nil {
+		cli.Log.Error("getting WebAPI version", "error", err)
+		return "", fmt.Errorf("getting WebAPI version: %w", err)
+	}
+
+	cli.webAPIVersion = strings.TrimSpace(string(body))
+	cli.Log.Debug("WebAPI version", "version", cli.webAPIVersion)
+	return cli.webAPIVersion, nil
+}
+
+// WebAPIAtLeast reports whether the server WebAPI version is equal to or newer than the given one.
+func (cli *Client) WebAPIAtLeast(ctx context.Context, version string) (bool, error) {
+	current, err := cli.WebAPIVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+	return compareVersions(current, version) >= 0, nil
+}
+
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var an, bn int
+		if i < len(as) {
+			an, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			bn, _ = strconv.Atoi(bs[i])
+		}
+		if an != bn {
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
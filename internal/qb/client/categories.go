@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type Category struct {
+	Name     string `json:"name"`
+	SavePath string `json:"savePath"`
+}
+
+func (cli *Client) Categories(ctx context.Context) (map[string]Category, error) {
+	categories := map[string]Category{}
+	if err := cli.GetJSON(ctx, "torrents/categories", nil, &categories, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting categories", "error", err)
+		return nil, fmt.Errorf("getting categories: %w", err)
+	}
+	return categories, nil
+}
+
+func (cli *Client) CreateCategory(ctx context.Context, name string, savePath string) error {
+	return cli.categoryAction(ctx, "create", "torrents/createCategory", url.Values{
+		"category": {name},
+		"savePath": {savePath},
+	})
+}
+
+func (cli *Client) EditCategory(ctx context.Context, name string, savePath string) error {
+	return cli.categoryAction(ctx, "edit", "torrents/editCategory", url.Values{
+		"category": {name},
+		"savePath": {savePath},
+	})
+}
+
+func (cli *Client) RemoveCategories(ctx context.Context, names []string) error {
+	return cli.categoryAction(ctx, "remove", "torrents/removeCategories", url.Values{
+		"categories": {strings.Join(names, "\n")},
+	})
+}
+
+// SetCategory assigns a category to torrents; an empty category removes it.
+func (cli *Client) SetCategory(ctx context.Context, hashes []string, category string) error {
+	form := hashesForm(hashes)
+	form.Set("category", category)
+	return cli.torrentsAction(ctx, "set category of", "torrents/setCategory", form)
+}
+
+func (cli *Client) categoryAction(ctx context.Context, action string, path string, form url.Values) error {
+	log := cli.Log.With("action", action, "category", form.Get("category")+form.Get("categories"))
+
+	if _, _, err := cli.PostForm(ctx, path, nil, form, cli.SessionAuth); err != nil {
+		log.Error("category action failed", "error", err)
+		return fmt.Errorf("%s category: %w", action, err)
+	}
+
+	log.Info("category action done")
+	return nil
+}
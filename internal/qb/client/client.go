@@ -17,19 +17,20 @@ import (
 )
 
 type Client struct {
-	credentials  *credentials.Credentials
-	cookieJar    *cookiejar.CookieJar
-	cachedCookie *http.Cookie
-	baseEndpoint string
-	apiVersion   string
-	forceAuth    bool
-	retry        bool
-	retryCount   int
-	retryDelay   time.Duration
-	maxRetries   int
-	timeOut      time.Duration
-	Log          *slog.Logger
-	LogLevel     *slog.LevelVar
+	credentials   *credentials.Credentials
+	cookieJar     *cookiejar.CookieJar
+	cachedCookie  *http.Cookie
+	baseEndpoint  string
+	apiVersion    string
+	webAPIVersion string
+	forceAuth     bool
+	retry         bool
+	retryCount    int
+	retryDelay    time.Duration
+	maxRetries    int
+	timeOut       time.Duration
+	Log           *slog.Logger
+	LogLevel      *slog.LevelVar
 }
 
 type Option func(*Client)
@@ -248,6 +249,58 @@ func (cli *Client) PostForm(
 	return cli.Fetch(ctx, req, auth)
 }
 
+func (cli *Client) PostMultipart(
+	ctx context.Context,
+	path string,
+	params url.Values,
+	fields url.Values,
+	files []FormFile,
+	auth func(ctx context.Context) (*http.Cookie, bool, error),
+) ([]byte, *http.Response, error) {
+	req, err := cli.PrepareMultipart(ctx, "POST", path, params, fields, files)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cli.Fetch(ctx, req, auth)
+}
+
+func (cli *Client) GetJSON(
+	ctx context.Context,
+	path string,
+	params url.Values,
+	out any,
+	auth func(ctx context.Context) (*http.Cookie, bool, error),
+) error {
+	body, _, err := cli.Get(ctx, path, params, nil, auth)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(body, out); err != nil {
+		return FatalErrorFrom(err, "unmarshalling response")
+	}
+	return nil
+}
+
+func (cli *Client) PostFormJSON(
+	ctx context.Context,
+	path string,
+	params url.Values,
+	form url.Values,
+	out any,
+	auth func(ctx context.Context) (*http.Cookie, bool, error),
+) error {
+	body, _, err := cli.PostForm(ctx, path, params, form, auth)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(body, out); err != nil {
+		return FatalErrorFrom(err, "unmarshalling response")
+	}
+	return nil
+}
+
 func (cli *Client) DoResource(
 	ctx context.Context,
 	method string,
@@ -62,3 +62,16 @@ func (e RequestError) Error() string     { return e.Err.Error() }
 func (e RequestError) Unwrap() error     { return e.Err }
 func (e RequestError) IsTransient() bool { return e.isTransient }
 func (e RequestError) IsFatal() bool     { return !e.isTransient }
+
+// PreferenceMismatchError reports a preference that still differs from the requested value
+// after qBittorrent accepted the change.
+type PreferenceMismatchError struct {
+	Key  string
+	Want any
+	Got  any
+}
+
+func (e PreferenceMismatchError) Error() string {
+	// %#v quotes strings, so that empty interfaces and addresses still show
+	return fmt.Sprintf("qBittorrent did not apply %s: got %#v, want %#v", e.Key, e.Got, e.Want)
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// File priorities as defined by the WebAPI.
+const (
+	PriorityDoNotDownload = 0
+	PriorityNormal        = 1
+	PriorityHigh          = 6
+	PriorityMaximum       = 7
+)
+
+// ContentFile is a file within a torrent, as returned by torrents/files.
+type ContentFile struct {
+	Index        int     `json:"index"`
+	Name         string  `json:"name"`
+	Size         int64   `json:"size"`
+	Progress     float64 `json:"progress"`
+	Priority     int     `json:"priority"`
+	IsSeed       bool    `json:"is_seed"`
+	PieceRange   []int   `json:"piece_range"`
+	Availability float64 `json:"availability"`
+}
+
+func PriorityString(priority int) string {
+	switch priority {
+	case PriorityDoNotDownload:
+		return "skip"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	case PriorityMaximum:
+		return "max"
+	default:
+		return strconv.Itoa(priority)
+	}
+}
+
+func (cli *Client) Files(ctx context.Context, hash string) ([]ContentFile, error) {
+	var files []ContentFile
+	params := url.Values{"hash": {hash}}
+	if err := cli.GetJSON(ctx, "torrents/files", params, &files, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting torrent files", "hash", hash, "error", err)
+		return nil, fmt.Errorf("getting torrent files: %w", err)
+	}
+
+	// WebAPI versions before 2.8.2 do not report the index, which is the position in the list
+	for i := range files {
+		if files[i].Index == 0 {
+			files[i].Index = i
+		}
+	}
+	return files, nil
+}
+
+func (cli *Client) SetFilePriority(ctx context.Context, hash string, indexes []int, priority int) error {
+	ids := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		ids = append(ids, strconv.Itoa(index))
+	}
+
+	form := url.Values{
+		"hash":     {hash},
+		"id":       {strings.Join(ids, "|")},
+		"priority": {strconv.Itoa(priority)},
+	}
+
+	if _, _, err := cli.PostForm(ctx, "torrents/filePrio", nil, form, cli.SessionAuth); err != nil {
+		cli.Log.Error("setting file priority", "hash", hash, "ids", ids, "priority", priority, "error", err)
+		return fmt.Errorf("setting file priority: %w", err)
+	}
+
+	cli.Log.Info("file priority set", "hash", hash, "ids", ids, "priority", priority)
+	return nil
+}
+
+func (cli *Client) RenameFile(ctx context.Context, hash string, oldPath string, newPath string) error {
+	return cli.renamePath(ctx, "torrents/renameFile", hash, oldPath, newPath)
+}
+
+func (cli *Client) RenameFolder(ctx context.Context, hash string, oldPath string, newPath string) error {
+	return cli.renamePath(ctx, "torrents/renameFolder", hash, oldPath, newPath)
+}
+
+func (cli *Client) renamePath(ctx context.Context, path string, hash string, oldPath string, newPath string) error {
+	form := url.Values{
+		"hash":    {hash},
+		"oldPath": {oldPath},
+		"newPath": {newPath},
+	}
+
+	log := cli.Log.With("endpoint", path, "hash", hash, "oldPath", oldPath, "newPath", newPath)
+	if _, _, err := cli.PostForm(ctx, path, nil, form, cli.SessionAuth); err != nil {
+		log.Error("renaming", "error", err)
+		return fmt.Errorf("renaming %s: %w", oldPath, err)
+	}
+
+	log.Info("renamed")
+	return nil
+}
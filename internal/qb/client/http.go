@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"mime/multipart"
 	"net"
 	"net/http"
 	"net/url"
@@ -85,6 +86,52 @@ func (cli *Client) PrepareForm(
 	return cli.Prepare(ctx, method, path, params, headers, payload)
 }
 
+type FormFile struct {
+	Field    string
+	FileName string
+	Content  []byte
+}
+
+func (cli *Client) PrepareMultipart(
+	ctx context.Context,
+	method string,
+	path string,
+	params url.Values,
+	fields url.Values,
+	files []FormFile,
+) (*http.Request, error) {
+	var payload bytes.Buffer
+	writer := multipart.NewWriter(&payload)
+
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.Field, file.FileName)
+		if err != nil {
+			return nil, FatalErrorFrom(err, "creating multipart file %s", file.FileName)
+		}
+		if _, err := part.Write(file.Content); err != nil {
+			return nil, FatalErrorFrom(err, "writing multipart file %s", file.FileName)
+		}
+	}
+
+	for field, values := range fields {
+		for _, value := range values {
+			if err := writer.WriteField(field, value); err != nil {
+				return nil, FatalErrorFrom(err, "writing multipart field %s", field)
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, FatalErrorFrom(err, "closing multipart payload")
+	}
+
+	headers := map[string]string{
+		"Content-Type": writer.FormDataContentType(),
+	}
+
+	return cli.Prepare(ctx, method, path, params, headers, payload.Bytes())
+}
+
 func (cli *Client) fetchRequest(ctx context.Context, req *http.Request) ([]byte, *http.Response, error) {
 	log := cli.Log.With("method", req.Method, "url", req.URL.String())
 	log.Debug("executing HTTP request")
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Main log message types, as bit flags reported by log/main.
+const (
+	LogNormal   = 1
+	LogInfo     = 2
+	LogWarning  = 4
+	LogCritical = 8
+)
+
+type LogEntry struct {
+	ID        int    `json:"id"`
+	Message   string `json:"message"`
+	Timestamp int64  `json:"timestamp"`
+	Type      int    `json:"type"`
+}
+
+func (e LogEntry) Severity() string {
+	switch e.Type {
+	case LogNormal:
+		return "normal"
+	case LogInfo:
+		return "info"
+	case LogWarning:
+		return "warning"
+	case LogCritical:
+		return "critical"
+	default:
+		return strconv.Itoa(e.Type)
+	}
+}
+
+type PeerLogEntry struct {
+	ID        int    `json:"id"`
+	IP        string `json:"ip"`
+	Timestamp int64  `json:"timestamp"`
+	Blocked   bool   `json:"blocked"`
+	Reason    string `json:"reason"`
+}
+
+// MainLogOptions selects the severities to include; entries up to LastKnownID are skipped (-1 for all).
+type MainLogOptions struct {
+	Normal      bool
+	Info        bool
+	Warning     bool
+	Critical    bool
+	LastKnownID int
+}
+
+func (opts MainLogOptions) Values() url.Values {
+	return url.Values{
+		"normal":        {strconv.FormatBool(opts.Normal)},
+		"info":          {strconv.FormatBool(opts.Info)},
+		"warning":       {strconv.FormatBool(opts.Warning)},
+		"critical":      {strconv.FormatBool(opts.Critical)},
+		"last_known_id": {strconv.Itoa(opts.LastKnownID)},
+	}
+}
+
+func (cli *Client) MainLog(ctx context.Context, opts MainLogOptions) ([]LogEntry, error) {
+	var entries []LogEntry
+	if err := cli.GetJSON(ctx, "log/main", opts.Values(), &entries, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting main log", "error", err)
+		return nil, fmt.Errorf("getting main log: %w", err)
+	}
+	return entries, nil
+}
+
+// PeerLog returns the peer log entries after lastKnownID (-1 for all).
+func (cli *Client) PeerLog(ctx context.Context, lastKnownID int) ([]PeerLogEntry, error) {
+	var entries []PeerLogEntry
+	params := url.Values{"last_known_id": {strconv.Itoa(lastKnownID)}}
+	if err := cli.GetJSON(ctx, "log/peers", params, &entries, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting peer log", "error", err)
+		return nil, fmt.Errorf("getting peer log: %w", err)
+	}
+	return entries, nil
+}
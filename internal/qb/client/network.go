@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// NetworkInterface is an interface qBittorrent can bind to. Value is the identifier stored in
+// current_network_interface; it equals Name on Linux but not on Windows.
+type NetworkInterface struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// NetworkBinding is the interface and address qBittorrent listens and connects on.
+// Empty values mean any interface and all of its addresses.
+type NetworkBinding struct {
+	Interface string `json:"interface"`
+	Address   string `json:"address"`
+}
+
+func (cli *Client) NetworkInterfaces(ctx context.Context) ([]NetworkInterface, error) {
+	var ifaces []NetworkInterface
+	if err := cli.GetJSON(ctx, "app/networkInterfaceList", nil, &ifaces, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting network interfaces", "error", err)
+		return nil, fmt.Errorf("getting network interfaces: %w", err)
+	}
+	return ifaces, nil
+}
+
+// NetworkInterfaceAddresses returns the addresses of an interface, or of all interfaces when iface is empty.
+func (cli *Client) NetworkInterfaceAddresses(ctx context.Context, iface string) ([]string, error) {
+	var addresses []string
+	params := url.Values{"iface": {iface}}
+	if err := cli.GetJSON(ctx, "app/networkInterfaceAddressList", params, &addresses, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting network interface addresses", "iface", iface, "error", err)
+		return nil, fmt.Errorf("getting network interface addresses: %w", err)
+	}
+	return addresses, nil
+}
+
+func (cli *Client) GetNetworkBinding(ctx context.Context) (NetworkBinding, error) {
+	prefs, err := cli.GetPreferences(ctx)
+	if err != nil {
+		cli.Log.Error("getting network binding", "error", err)
+		return NetworkBinding{}, fmt.Errorf("getting network binding: %w", err)
+	}
+	return networkBindingFrom(prefs), nil
+}
+
+// EnsureNetworkBinding binds qBittorrent to the given interface and address unless it already is,
+// reading the preferences back to verify they were applied. It reports whether preferences were written.
+func (cli *Client) EnsureNetworkBinding(ctx context.Context, binding NetworkBinding) (bool, error) {
+	current, err := cli.GetNetworkBinding(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if current == binding {
+		cli.Log.Debug("network binding already set", "interface", binding.Interface, "address", binding.Address)
+		return false, nil
+	}
+
+	err = cli.SetPreferences(ctx, map[string]any{
+		"current_network_interface": binding.Interface,
+		"current_interface_address": binding.Address,
+	})
+	if err != nil {
+		cli.Log.Error("setting network binding", "interface", binding.Interface, "address", binding.Address, "error", err)
+		return false, fmt.Errorf("setting network binding: %w", err)
+	}
+
+	got, err := cli.GetNetworkBinding(ctx)
+	if err != nil {
+		return true, fmt.Errorf("verifying network binding: %w", err)
+	}
+
+	switch {
+	case got.Interface != binding.Interface:
+		cli.Log.Error("network interface not applied", "interface", binding.Interface, "actual", got.Interface)
+		return true, PreferenceMismatchError{Key: "current_network_interface", Want: binding.Interface, Got: got.Interface}
+	case got.Address != binding.Address:
+		cli.Log.Error("interface address not applied", "address", binding.Address, "actual", got.Address)
+		return true, PreferenceMismatchError{Key: "current_interface_address", Want: binding.Address, Got: got.Address}
+	}
+
+	cli.Log.Info("network binding set", "interface", binding.Interface, "address", binding.Address,
+		"previous_interface", current.Interface, "previous_address", current.Address)
+	return true, nil
+}
+
+func networkBindingFrom(prefs map[string]any) NetworkBinding {
+	iface, _ := prefs["current_network_interface"].(string)
+	address, _ := prefs["current_interface_address"].(string)
+	return NetworkBinding{Interface: iface, Address: address}
+}
@@ -0,0 +1,71 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/qb/qbtest"
+)
+
+func TestEnsureNetworkBinding(t *testing.T) {
+	tests := []struct {
+		name         string
+		current      client.NetworkBinding
+		ignoreWrites bool
+		wantChanged  bool
+		wantKey      string
+		wantError    string
+	}{
+		{name: "no-op", current: client.NetworkBinding{Interface: "tun0", Address: "10.2.0.2"}},
+		{name: "write", current: client.NetworkBinding{Interface: "eth0"}, wantChanged: true},
+		{name: "address only", current: client.NetworkBinding{Interface: "tun0"}, wantChanged: true},
+		{name: "ignored", current: client.NetworkBinding{Interface: "eth0"}, ignoreWrites: true,
+			wantChanged: true, wantKey: "current_network_interface"},
+		{name: "address ignored", current: client.NetworkBinding{Interface: "tun0"}, ignoreWrites: true,
+			wantChanged: true, wantKey: "current_interface_address",
+			wantError: `qBittorrent did not apply current_interface_address: got "", want "10.2.0.2"`},
+	}
+
+	want := client.NetworkBinding{Interface: "tun0", Address: "10.2.0.2"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := qbtest.NewServer(t)
+			prefs := server.Preferences(map[string]any{
+				"current_network_interface": tt.current.Interface,
+				"current_interface_address": tt.current.Address,
+			})
+			prefs.IgnoreWrites(tt.ignoreWrites)
+
+			changed, err := server.Client(t).EnsureNetworkBinding(context.Background(), want)
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+
+			if tt.wantKey != "" {
+				var mismatch client.PreferenceMismatchError
+				if !errors.As(err, &mismatch) || mismatch.Key != tt.wantKey {
+					t.Fatalf("error = %v, want a mismatch on %s", err, tt.wantKey)
+				}
+				if tt.wantError != "" && mismatch.Error() != tt.wantError {
+					t.Errorf("error = %q, want %q", mismatch.Error(), tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tt.wantChanged && prefs.Writes() != 0 {
+				t.Error("preferences written although the binding was set")
+			}
+			if got := prefs.Get("current_network_interface"); got != want.Interface {
+				t.Errorf("current_network_interface = %v, want %s", got, want.Interface)
+			}
+			if got := prefs.Get("current_interface_address"); got != want.Address {
+				t.Errorf("current_interface_address = %v, want %s", got, want.Address)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type Peer struct {
+	IP           string  `json:"ip"`
+	Port         int     `json:"port"`
+	Client       string  `json:"client"`
+	PeerIDClient string  `json:"peer_id_client"`
+	Country      string  `json:"country"`
+	CountryCode  string  `json:"country_code"`
+	Connection   string  `json:"connection"`
+	Flags        string  `json:"flags"`
+	FlagsDesc    string  `json:"flags_desc"`
+	Progress     float64 `json:"progress"`
+	DlSpeed      int64   `json:"dl_speed"`
+	UpSpeed      int64   `json:"up_speed"`
+	Downloaded   int64   `json:"downloaded"`
+	Uploaded     int64   `json:"uploaded"`
+	Relevance    float64 `json:"relevance"`
+	Files        string  `json:"files"`
+}
+
+func (p Peer) Address() string {
+	return net.JoinHostPort(p.IP, strconv.Itoa(p.Port))
+}
+
+type torrentPeers struct {
+	RID        int             `json:"rid"`
+	FullUpdate bool            `json:"full_update"`
+	Peers      map[string]Peer `json:"peers"`
+}
+
+// Peers returns the peers of a torrent keyed by "ip:port", using a full sync/torrentPeers update.
+func (cli *Client) Peers(ctx context.Context, hash string) (map[string]Peer, error) {
+	var data torrentPeers
+	params := url.Values{"hash": {hash}, "rid": {"0"}}
+	if err := cli.GetJSON(ctx, "sync/torrentPeers", params, &data, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting torrent peers", "hash", hash, "error", err)
+		return nil, fmt.Errorf("getting torrent peers: %w", err)
+	}
+
+	if data.Peers == nil {
+		data.Peers = map[string]Peer{}
+	}
+	return data.Peers, nil
+}
+
+// AddPeers adds peers given as "host:port" to the torrents.
+func (cli *Client) AddPeers(ctx context.Context, hashes []string, peers []string) error {
+	form := hashesForm(hashes)
+	form.Set("peers", strings.Join(peers, "|"))
+	return cli.torrentsAction(ctx, "add peers to", "torrents/addPeers", form)
+}
+
+// BanPeers permanently bans peers given as "host:port"; qBittorrent bans the whole IP address.
+func (cli *Client) BanPeers(ctx context.Context, peers []string) error {
+	if len(peers) == 0 {
+		return fmt.Errorf("ban peers: no peers given")
+	}
+
+	form := url.Values{"peers": {strings.Join(peers, "|")}}
+	if _, _, err := cli.PostForm(ctx, "transfer/banPeers", nil, form, cli.SessionAuth); err != nil {
+		cli.Log.Error("banning peers", "peers", peers, "error", err)
+		return fmt.Errorf("banning peers: %w", err)
+	}
+
+	cli.Log.Info("peers banned", "peers", peers)
+	return nil
+}
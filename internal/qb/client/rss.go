@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+type RSSArticle struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Date        string `json:"date"`
+	Link        string `json:"link"`
+	TorrentURL  string `json:"torrentURL"`
+	Author      string `json:"author"`
+	Category    string `json:"category"`
+	IsRead      bool   `json:"isRead"`
+}
+
+type RSSFeed struct {
+	UID           string       `json:"uid"`
+	URL           string       `json:"url"`
+	Title         string       `json:"title"`
+	LastBuildDate string       `json:"lastBuildDate"`
+	IsLoading     bool         `json:"isLoading"`
+	HasError      bool         `json:"hasError"`
+	Articles      []RSSArticle `json:"articles"`
+}
+
+func (f RSSFeed) Unread() int {
+	unread := 0
+	for _, a := range f.Articles {
+		if !a.IsRead {
+			unread++
+		}
+	}
+	return unread
+}
+
+// RSSItem is a node of the RSS tree: either a folder with children or a feed.
+type RSSItem struct {
+	Path     string    `json:"path"`
+	Feed     *RSSFeed  `json:"feed,omitempty"`
+	Children []RSSItem `json:"children,omitempty"`
+}
+
+func (item RSSItem) IsFolder() bool {
+	return item.Feed == nil
+}
+
+// Feeds flattens the tree into its feeds, keyed by item path.
+func (item RSSItem) Feeds() map[string]RSSFeed {
+	feeds := map[string]RSSFeed{}
+	if item.Feed != nil {
+		feeds[item.Path] = *item.Feed
+	}
+	for _, child := range item.Children {
+		for path, feed := range child.Feeds() {
+			feeds[path] = feed
+		}
+	}
+	return feeds
+}
+
+// RSSPathSeparator separates folders in RSS item paths.
+const RSSPathSeparator = `\`
+
+// RSSItems returns the RSS tree rooted at an unnamed folder. With withData set, feeds include their articles.
+func (cli *Client) RSSItems(ctx context.Context, withData bool) (*RSSItem, error) {
+	var raw map[string]json.RawMessage
+	params := url.Values{"withData": {strconv.FormatBool(withData)}}
+	if err := cli.GetJSON(ctx, "rss/items", params, &raw, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting RSS items", "error", err)
+		return nil, fmt.Errorf("getting RSS items: %w", err)
+	}
+
+	root, err := parseRSSFolder("", raw)
+	if err != nil {
+		cli.Log.Error("parsing RSS items", "error", err)
+		return nil, fmt.Errorf("parsing RSS items: %w", err)
+	}
+	return root, nil
+}
+
+func parseRSSFolder(path string, raw map[string]json.RawMessage) (*RSSItem, error) {
+	folder := &RSSItem{Path: path}
+
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		childPath := name
+		if path != "" {
+			childPath = path + RSSPathSeparator + name
+		}
+
+		// Feeds are objects with an "uid" (or, without data, plain URL strings in old releases); the rest are folders
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw[name], &fields); err != nil {
+			var feedURL string
+			if err := json.Unmarshal(raw[name], &feedURL); err != nil {
+				return nil, fmt.Errorf("invalid RSS item %s", childPath)
+			}
+			folder.Children = append(folder.Children, RSSItem{Path: childPath, Feed: &RSSFeed{URL: feedURL}})
+			continue
+		}
+
+		if _, isFeed := fields["uid"]; isFeed {
+			var feed RSSFeed
+			if err := json.Unmarshal(raw[name], &feed); err != nil {
+				return nil, fmt.Errorf("invalid RSS feed %s: %w", childPath, err)
+			}
+			folder.Children = append(folder.Children, RSSItem{Path: childPath, Feed: &feed})
+			continue
+		}
+
+		child, err := parseRSSFolder(childPath, fields)
+		if err != nil {
+			return nil, err
+		}
+		folder.Children = append(folder.Children, *child)
+	}
+	return folder, nil
+}
+
+func (cli *Client) AddRSSFolder(ctx context.Context, path string) error {
+	return cli.rssAction(ctx, "add folder", "rss/addFolder", url.Values{"path": {path}})
+}
+
+// AddRSSFeed subscribes to a feed; path is the item path, defaulting to the feed title when empty.
+func (cli *Client) AddRSSFeed(ctx context.Context, feedURL string, path string) error {
+	form := url.Values{"url": {feedURL}}
+	if path != "" {
+		form.Set("path", path)
+	}
+	return cli.rssAction(ctx, "add feed", "rss/addFeed", form)
+}
+
+func (cli *Client) RemoveRSSItem(ctx context.Context, path string) error {
+	return cli.rssAction(ctx, "remove item", "rss/removeItem", url.Values{"path": {path}})
+}
+
+func (cli *Client) MoveRSSItem(ctx context.Context, itemPath string, destPath string) error {
+	return cli.rssAction(ctx, "move item", "rss/moveItem", url.Values{"itemPath": {itemPath}, "destPath": {destPath}})
+}
+
+func (cli *Client) RefreshRSSItem(ctx context.Context, itemPath string) error {
+	return cli.rssAction(ctx, "refresh item", "rss/refreshItem", url.Values{"itemPath": {itemPath}})
+}
+
+// MarkRSSAsRead marks a whole item as read, or a single article when articleID is given.
+func (cli *Client) MarkRSSAsRead(ctx context.Context, itemPath string, articleID string) error {
+	form := url.Values{"itemPath": {itemPath}}
+	if articleID != "" {
+		form.Set("articleId", articleID)
+	}
+	return cli.rssAction(ctx, "mark as read", "rss/markAsRead", form)
+}
+
+// rssLoggedFields are the form fields logged by rssAction. Feed URLs and rule definitions are left
+// out: those of private trackers carry passkeys.
+var rssLoggedFields = []string{"path", "itemPath", "destPath", "articleId", "ruleName", "newRuleName"}
+
+func (cli *Client) rssAction(ctx context.Context, action string, path string, form url.Values) error {
+	log := cli.Log.With("action", action)
+	for _, key := range rssLoggedFields {
+		if form.Has(key) {
+			log = log.With(key, form.Get(key))
+		}
+	}
+
+	if _, _, err := cli.PostForm(ctx, path, nil, form, cli.SessionAuth); err != nil {
+		log.Error("RSS action failed", "error", err)
+		return fmt.Errorf("RSS %s: %w", action, err)
+	}
+
+	log.Info("RSS action done")
+	return nil
+}
+
+// RSSRules returns the auto-downloading rules keyed by name. Rules are kept as generic maps,
+// like preferences, since their fields vary between qBittorrent releases.
+func (cli *Client) RSSRules(ctx context.Context) (map[string]map[string]any, error) {
+	rules := map[string]map[string]any{}
+	if err := cli.GetJSON(ctx, "rss/rules", nil, &rules, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting RSS rules", "error", err)
+		return nil, fmt.Errorf("getting RSS rules: %w", err)
+	}
+	return rules, nil
+}
+
+// SetRSSRule creates or replaces a rule.
+func (cli *Client) SetRSSRule(ctx context.Context, name string, def map[string]any) error {
+	ruleDef, err := json.Marshal(def)
+	if err != nil {
+		cli.Log.Error("failed to marshal RSS rule", "rule", name, "error", err)
+		return fmt.Errorf("failed to marshal RSS rule: %w", err)
+	}
+	return cli.rssAction(ctx, "set rule", "rss/setRule", url.Values{"ruleName": {name}, "ruleDef": {string(ruleDef)}})
+}
+
+func (cli *Client) RenameRSSRule(ctx context.Context, name string, newName string) error {
+	return cli.rssAction(ctx, "rename rule", "rss/renameRule", url.Values{"ruleName": {name}, "newRuleName": {newName}})
+}
+
+func (cli *Client) RemoveRSSRule(ctx context.Context, name string) error {
+	return cli.rssAction(ctx, "remove rule", "rss/removeRule", url.Values{"ruleName": {name}})
+}
+
+// RSSMatchingArticles returns the titles of current articles matching a rule, keyed by feed name.
+func (cli *Client) RSSMatchingArticles(ctx context.Context, name string) (map[string][]string, error) {
+	articles := map[string][]string{}
+	params := url.Values{"ruleName": {name}}
+	if err := cli.GetJSON(ctx, "rss/matchingArticles", params, &articles, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting RSS matching articles", "rule", name, "error", err)
+		return nil, fmt.Errorf("getting RSS matching articles: %w", err)
+	}
+	return articles, nil
+}
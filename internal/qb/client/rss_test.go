@@ -0,0 +1,40 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/qb/qbtest"
+)
+
+func TestRSSActionsDoNotLogPasskeys(t *testing.T) {
+	server := qbtest.NewServer(t)
+	server.HandleFunc("rss/addFeed", func(w http.ResponseWriter, r *http.Request) {})
+	server.HandleFunc("rss/setRule", func(w http.ResponseWriter, r *http.Request) {})
+
+	var logs bytes.Buffer
+	cli := server.Client(t, client.WithCustomLogger(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	ctx := context.Background()
+
+	const feedURL = "https://tracker.example/rss?passkey=s3cr3t"
+	if err := cli.AddRSSFeed(ctx, feedURL, "Private"); err != nil {
+		t.Fatalf("AddRSSFeed() = %v", err)
+	}
+	if err := cli.SetRSSRule(ctx, "My Show", map[string]any{"affectedFeeds": []string{feedURL}}); err != nil {
+		t.Fatalf("SetRSSRule() = %v", err)
+	}
+
+	if strings.Contains(logs.String(), "s3cr3t") {
+		t.Errorf("passkey logged:\n%s", logs.String())
+	}
+	for _, want := range []string{"path=Private", `ruleName="My Show"`} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("log is missing %s:\n%s", want, logs.String())
+		}
+	}
+}
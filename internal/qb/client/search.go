@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Search job states reported by search/status.
+const (
+	SearchRunning = "Running"
+	SearchStopped = "Stopped"
+)
+
+type SearchJob struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+	Total  int    `json:"total"`
+}
+
+func (job SearchJob) IsRunning() bool {
+	return job.Status == SearchRunning
+}
+
+type SearchResult struct {
+	FileName   string `json:"fileName"`
+	FileURL    string `json:"fileUrl"`
+	FileSize   int64  `json:"fileSize"`
+	NbSeeders  int    `json:"nbSeeders"`
+	NbLeechers int    `json:"nbLeechers"`
+	SiteURL    string `json:"siteUrl"`
+	DescrLink  string `json:"descrLink"`
+	EngineName string `json:"engineName,omitempty"`
+	PubDate    int64  `json:"pubDate,omitempty"`
+}
+
+type SearchResults struct {
+	Results []SearchResult `json:"results"`
+	Status  string         `json:"status"`
+	Total   int            `json:"total"`
+}
+
+// SearchOptions selects the plugins ("all", "enabled" or plugin names) and category ("all" or a category id).
+type SearchOptions struct {
+	Pattern  string
+	Plugins  []string
+	Category string
+}
+
+func (opts SearchOptions) Values() url.Values {
+	plugins := "enabled"
+	if len(opts.Plugins) > 0 {
+		plugins = strings.Join(opts.Plugins, "|")
+	}
+
+	category := opts.Category
+	if category == "" {
+		category = "all"
+	}
+
+	return url.Values{
+		"pattern":  {opts.Pattern},
+		"plugins":  {plugins},
+		"category": {category},
+	}
+}
+
+// StartSearch starts a search job and returns its id.
+func (cli *Client) StartSearch(ctx context.Context, opts SearchOptions) (int, error) {
+	var job SearchJob
+	if err := cli.PostFormJSON(ctx, "search/start", nil, opts.Values(), &job, cli.SessionAuth); err != nil {
+		cli.Log.Error("starting search", "pattern", opts.Pattern, "error", err)
+		return 0, fmt.Errorf("starting search: %w", err)
+	}
+
+	cli.Log.Debug("search started", "id", job.ID, "pattern", opts.Pattern)
+	return job.ID, nil
+}
+
+func (cli *Client) SearchStatus(ctx context.Context, id int) (SearchJob, error) {
+	var jobs []SearchJob
+	params := url.Values{"id": {strconv.Itoa(id)}}
+	if err := cli.GetJSON(ctx, "search/status", params, &jobs, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting search status", "id", id, "error", err)
+		return SearchJob{}, fmt.Errorf("getting search status: %w", err)
+	}
+
+	if len(jobs) == 0 {
+		return SearchJob{}, fmt.Errorf("getting search status: search job %d not found", id)
+	}
+	return jobs[0], nil
+}
+
+// SearchResults returns the results of a search job, starting at offset; limit 0 returns all of them.
+func (cli *Client) SearchResults(ctx context.Context, id int, limit int, offset int) (*SearchResults, error) {
+	params := url.Values{"id": {strconv.Itoa(id)}}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	if offset != 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+
+	var results SearchResults
+	if err := cli.GetJSON(ctx, "search/results", params, &results, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting search results", "id", id, "error", err)
+		return nil, fmt.Errorf("getting search results: %w", err)
+	}
+	return &results, nil
+}
+
+func (cli *Client) StopSearch(ctx context.Context, id int) error {
+	return cli.searchAction(ctx, "stop", "search/stop", id)
+}
+
+func (cli *Client) DeleteSearch(ctx context.Context, id int) error {
+	return cli.searchAction(ctx, "delete", "search/delete", id)
+}
+
+func (cli *Client) searchAction(ctx context.Context, action string, path string, id int) error {
+	log := cli.Log.With("action", action, "id", id)
+
+	form := url.Values{"id": {strconv.Itoa(id)}}
+	if _, _, err := cli.PostForm(ctx, path, nil, form, cli.SessionAuth); err != nil {
+		log.Error("search action failed", "error", err)
+		return fmt.Errorf("search %s: %w", action, err)
+	}
+
+	log.Debug("search action done")
+	return nil
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type SearchCategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UnmarshalJSON also accepts the plain category names reported before qBittorrent 4.3.
+func (c *SearchCategory) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*c = SearchCategory{ID: name, Name: name}
+		return nil
+	}
+
+	type category SearchCategory
+	return json.Unmarshal(data, (*category)(c))
+}
+
+type SearchPlugin struct {
+	Name                string           `json:"name"`
+	FullName            string           `json:"fullName"`
+	Version             string           `json:"version"`
+	URL                 string           `json:"url"`
+	Enabled             bool             `json:"enabled"`
+	SupportedCategories []SearchCategory `json:"supportedCategories"`
+}
+
+func (cli *Client) SearchPlugins(ctx context.Context) ([]SearchPlugin, error) {
+	var plugins []SearchPlugin
+	if err := cli.GetJSON(ctx, "search/plugins", nil, &plugins, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting search plugins", "error", err)
+		return nil, fmt.Errorf("getting search plugins: %w", err)
+	}
+	return plugins, nil
+}
+
+// InstallSearchPlugins installs plugins from URLs or from paths on the qBittorrent host.
+// Installation happens in the background, so plugins may not be listed right away.
+func (cli *Client) InstallSearchPlugins(ctx context.Context, sources []string) error {
+	return cli.searchPluginsAction(ctx, "install", "search/installPlugin", url.Values{"sources": {strings.Join(sources, "|")}})
+}
+
+func (cli *Client) UninstallSearchPlugins(ctx context.Context, names []string) error {
+	return cli.searchPluginsAction(ctx, "uninstall", "search/uninstallPlugin", url.Values{"names": {strings.Join(names, "|")}})
+}
+
+func (cli *Client) EnableSearchPlugins(ctx context.Context, names []string, enable bool) error {
+	form := url.Values{"names": {strings.Join(names, "|")}, "enable": {strconv.FormatBool(enable)}}
+	return cli.searchPluginsAction(ctx, "enable", "search/enablePlugin", form)
+}
+
+func (cli *Client) UpdateSearchPlugins(ctx context.Context) error {
+	return cli.searchPluginsAction(ctx, "update", "search/updatePlugins", url.Values{})
+}
+
+// searchPluginsAction logs plugin names but only the number of sources: source URLs can carry
+// credentials.
+func (cli *Client) searchPluginsAction(ctx context.Context, action string, path string, form url.Values) error {
+	log := cli.Log.With("action", action)
+	if form.Has("sources") {
+		log = log.With("sources", len(strings.Split(form.Get("sources"), "|")))
+	}
+	for _, key := range []string{"names", "enable"} {
+		if form.Has(key) {
+			log = log.With(key, form.Get(key))
+		}
+	}
+
+	if _, _, err := cli.PostForm(ctx, path, nil, form, cli.SessionAuth); err != nil {
+		log.Error("search plugins action failed", "error", err)
+		return fmt.Errorf("search plugins %s: %w", action, err)
+	}
+
+	log.Info("search plugins action done")
+	return nil
+}
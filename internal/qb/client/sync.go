@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// MainData is a sync/maindata response. Unless FullUpdate is set, it only carries the
+// fields that changed since the given rid, hence the raw per-field representation.
+type MainData struct {
+	RID               int                                   `json:"rid"`
+	FullUpdate        bool                                  `json:"full_update"`
+	Torrents          map[string]map[string]json.RawMessage `json:"torrents"`
+	TorrentsRemoved   []string                              `json:"torrents_removed"`
+	Categories        map[string]map[string]json.RawMessage `json:"categories"`
+	CategoriesRemoved []string                              `json:"categories_removed"`
+	Tags              []string                              `json:"tags"`
+	TagsRemoved       []string                              `json:"tags_removed"`
+	ServerState       map[string]json.RawMessage            `json:"server_state"`
+}
+
+type ServerState struct {
+	ConnectionStatus     string `json:"connection_status"`
+	DHTNodes             int    `json:"dht_nodes"`
+	DlInfoSpeed          int64  `json:"dl_info_speed"`
+	DlInfoData           int64  `json:"dl_info_data"`
+	UpInfoSpeed          int64  `json:"up_info_speed"`
+	UpInfoData           int64  `json:"up_info_data"`
+	DlRateLimit          int64  `json:"dl_rate_limit"`
+	UpRateLimit          int64  `json:"up_rate_limit"`
+	AllTimeDl            int64  `json:"alltime_dl"`
+	AllTimeUl            int64  `json:"alltime_ul"`
+	FreeSpaceOnDisk      int64  `json:"free_space_on_disk"`
+	GlobalRatio          string `json:"global_ratio"`
+	Queueing             bool   `json:"queueing"`
+	UseAltSpeedLimits    bool   `json:"use_alt_speed_limits"`
+	RefreshInterval      int    `json:"refresh_interval"`
+	TotalPeerConnections int    `json:"total_peer_connections"`
+}
+
+func (cli *Client) SyncMainData(ctx context.Context, rid int) (*MainData, error) {
+	var data MainData
+	params := url.Values{"rid": {strconv.Itoa(rid)}}
+	if err := cli.GetJSON(ctx, "sync/maindata", params, &data, cli.SessionAuth); err != nil {
+		cli.Log.Error("syncing main data", "rid", rid, "error", err)
+		return nil, fmt.Errorf("syncing main data: %w", err)
+	}
+
+	cli.Log.Debug("main data synced", "rid", data.RID, "fullUpdate", data.FullUpdate, "torrents", len(data.Torrents))
+	return &data, nil
+}
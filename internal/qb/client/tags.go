@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+func (cli *Client) Tags(ctx context.Context) ([]string, error) {
+	var tags []string
+	if err := cli.GetJSON(ctx, "torrents/tags", nil, &tags, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting tags", "error", err)
+		return nil, fmt.Errorf("getting tags: %w", err)
+	}
+	return tags, nil
+}
+
+func (cli *Client) CreateTags(ctx context.Context, tags []string) error {
+	return cli.tagsAction(ctx, "create", "torrents/createTags", url.Values{}, tags)
+}
+
+func (cli *Client) DeleteTags(ctx context.Context, tags []string) error {
+	return cli.tagsAction(ctx, "delete", "torrents/deleteTags", url.Values{}, tags)
+}
+
+func (cli *Client) AddTags(ctx context.Context, hashes []string, tags []string) error {
+	return cli.tagsAction(ctx, "add", "torrents/addTags", hashesForm(hashes), tags)
+}
+
+func (cli *Client) RemoveTags(ctx context.Context, hashes []string, tags []string) error {
+	return cli.tagsAction(ctx, "remove", "torrents/removeTags", hashesForm(hashes), tags)
+}
+
+func (cli *Client) tagsAction(ctx context.Context, action string, path string, form url.Values, tags []string) error {
+	log := cli.Log.With("action", action, "tags", tags)
+
+	if len(tags) == 0 {
+		log.Error("no tags given")
+		return fmt.Errorf("%s tags: no tags given", action)
+	}
+	form.Set("tags", strings.Join(tags, ","))
+
+	if _, _, err := cli.PostForm(ctx, path, nil, form, cli.SessionAuth); err != nil {
+		log.Error("tags action failed", "error", err)
+		return fmt.Errorf("%s tags: %w", action, err)
+	}
+
+	log.Info("tags action done", "hashes", form.Get("hashes"))
+	return nil
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Torrent creator task states reported by torrentcreator/status.
+const (
+	CreatorQueued   = "Queued"
+	CreatorRunning  = "Running"
+	CreatorFinished = "Finished"
+	CreatorFailed   = "Failed"
+)
+
+type TorrentCreatorTask struct {
+	TaskID          string   `json:"taskID"`
+	SourcePath      string   `json:"sourcePath"`
+	TorrentFilePath string   `json:"torrentFilePath,omitempty"`
+	Format          string   `json:"format,omitempty"`
+	PieceSize       int64    `json:"pieceSize"`
+	Private         bool     `json:"private"`
+	Comment         string   `json:"comment,omitempty"`
+	Source          string   `json:"source,omitempty"`
+	Trackers        []string `json:"trackers"`
+	URLSeeds        []string `json:"urlSeeds"`
+	Status          string   `json:"status"`
+	Progress        float64  `json:"progress"`
+	ErrorMessage    string   `json:"errorMessage,omitempty"`
+	TimeAdded       string   `json:"timeAdded"`
+	TimeStarted     string   `json:"timeStarted,omitempty"`
+	TimeFinished    string   `json:"timeFinished,omitempty"`
+}
+
+func (task TorrentCreatorTask) IsDone() bool {
+	return task.Status == CreatorFinished || task.Status == CreatorFailed
+}
+
+// CreateTorrentOptions describes a torrent creator task. SourcePath is a path on the qBittorrent host.
+// Format is one of v1, v2 or hybrid (empty for the server default) and a zero PieceSize lets
+// qBittorrent choose one.
+type CreateTorrentOptions struct {
+	SourcePath      string
+	TorrentFilePath string
+	Format          string
+	PieceSize       int64
+	Private         bool
+	StartSeeding    bool
+	Comment         string
+	Source          string
+	Trackers        []string
+	URLSeeds        []string
+}
+
+func (opts CreateTorrentOptions) Values() url.Values {
+	values := url.Values{
+		"sourcePath":   {opts.SourcePath},
+		"private":      {strconv.FormatBool(opts.Private)},
+		"startSeeding": {strconv.FormatBool(opts.StartSeeding)},
+	}
+	if opts.TorrentFilePath != "" {
+		values.Set("torrentFilePath", opts.TorrentFilePath)
+	}
+	if opts.Format != "" {
+		values.Set("format", opts.Format)
+	}
+	if opts.PieceSize > 0 {
+		values.Set("pieceSize", strconv.FormatInt(opts.PieceSize, 10))
+	}
+	if opts.Comment != "" {
+		values.Set("comment", opts.Comment)
+	}
+	if opts.Source != "" {
+		values.Set("source", opts.Source)
+	}
+	if len(opts.Trackers) > 0 {
+		values.Set("trackers", strings.Join(opts.Trackers, "|"))
+	}
+	if len(opts.URLSeeds) > 0 {
+		values.Set("urlSeeds", strings.Join(opts.URLSeeds, "|"))
+	}
+	return values
+}
+
+// AddTorrentCreatorTask submits a torrent creator task (qBittorrent 5.0+) and returns its id.
+func (cli *Client) AddTorrentCreatorTask(ctx context.Context, opts CreateTorrentOptions) (string, error) {
+	var task TorrentCreatorTask
+	if err := cli.PostFormJSON(ctx, "torrentcreator/addTask", nil, opts.Values(), &task, cli.SessionAuth); err != nil {
+		cli.Log.Error("adding torrent creator task", "sourcePath", opts.SourcePath, "error", err)
+		return "", fmt.Errorf("adding torrent creator task: %w", err)
+	}
+
+	cli.Log.Debug("torrent creator task added", "taskID", task.TaskID, "sourcePath", opts.SourcePath)
+	return task.TaskID, nil
+}
+
+func (cli *Client) TorrentCreatorStatus(ctx context.Context, taskID string) (TorrentCreatorTask, error) {
+	var tasks []TorrentCreatorTask
+	params := url.Values{"taskID": {taskID}}
+	if err := cli.GetJSON(ctx, "torrentcreator/status", params, &tasks, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting torrent creator status", "taskID", taskID, "error", err)
+		return TorrentCreatorTask{}, fmt.Errorf("getting torrent creator status: %w", err)
+	}
+
+	if len(tasks) == 0 {
+		return TorrentCreatorTask{}, fmt.Errorf("getting torrent creator status: task %s not found", taskID)
+	}
+	return tasks[0], nil
+}
+
+// TorrentCreatorFile downloads the .torrent file produced by a finished task.
+func (cli *Client) TorrentCreatorFile(ctx context.Context, taskID string) ([]byte, error) {
+	params := url.Values{"taskID": {taskID}}
+	body, _, err := cli.Get(ctx, "torrentcreator/torrentFile", params, nil, cli.SessionAuth)
+	if err != nil {
+		cli.Log.Error("getting created torrent file", "taskID", taskID, "error", err)
+		return nil, fmt.Errorf("getting created torrent file: %w", err)
+	}
+	return body, nil
+}
+
+func (cli *Client) DeleteTorrentCreatorTask(ctx context.Context, taskID string) error {
+	form := url.Values{"taskID": {taskID}}
+	if _, _, err := cli.PostForm(ctx, "torrentcreator/deleteTask", nil, form, cli.SessionAuth); err != nil {
+		cli.Log.Error("deleting torrent creator task", "taskID", taskID, "error", err)
+		return fmt.Errorf("deleting torrent creator task: %w", err)
+	}
+
+	cli.Log.Debug("torrent creator task deleted", "taskID", taskID)
+	return nil
+}
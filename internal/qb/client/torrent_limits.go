@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Share limit values with a special meaning in torrents/setShareLimits.
+const (
+	ShareLimitGlobal    = -2
+	ShareLimitUnlimited = -1
+)
+
+// ShareLimits holds the per-torrent share limits; time limits are in minutes.
+type ShareLimits struct {
+	RatioLimit               float64 `json:"ratio_limit"`
+	SeedingTimeLimit         int64   `json:"seeding_time_limit"`
+	InactiveSeedingTimeLimit int64   `json:"inactive_seeding_time_limit"`
+}
+
+// ShareLimits returns the share limits of a torrent as reported by torrents/info.
+func (t Torrent) ShareLimits() ShareLimits {
+	return ShareLimits{
+		RatioLimit:               t.RatioLimit,
+		SeedingTimeLimit:         t.SeedingTimeLimit,
+		InactiveSeedingTimeLimit: t.InactiveSeedingTimeLimit,
+	}
+}
+
+// SetShareLimits sets all three share limits at once, as required by qBittorrent.
+func (cli *Client) SetShareLimits(ctx context.Context, hashes []string, limits ShareLimits) error {
+	form := hashesForm(hashes)
+	form.Set("ratioLimit", strconv.FormatFloat(limits.RatioLimit, 'f', -1, 64))
+	form.Set("seedingTimeLimit", strconv.FormatInt(limits.SeedingTimeLimit, 10))
+	form.Set("inactiveSeedingTimeLimit", strconv.FormatInt(limits.InactiveSeedingTimeLimit, 10))
+	return cli.torrentsAction(ctx, "set share limits of", "torrents/setShareLimits", form)
+}
+
+// TorrentDownloadLimits returns the download limits in bytes/s keyed by hash (0 for unlimited).
+func (cli *Client) TorrentDownloadLimits(ctx context.Context, hashes []string) (map[string]int64, error) {
+	return cli.torrentRateLimits(ctx, "torrents/downloadLimit", hashes)
+}
+
+// TorrentUploadLimits returns the upload limits in bytes/s keyed by hash (0 for unlimited).
+func (cli *Client) TorrentUploadLimits(ctx context.Context, hashes []string) (map[string]int64, error) {
+	return cli.torrentRateLimits(ctx, "torrents/uploadLimit", hashes)
+}
+
+// SetTorrentDownloadLimit sets the download limit in bytes/s; 0 removes it.
+func (cli *Client) SetTorrentDownloadLimit(ctx context.Context, hashes []string, limit int64) error {
+	form := hashesForm(hashes)
+	form.Set("limit", strconv.FormatInt(limit, 10))
+	return cli.torrentsAction(ctx, "set download limit of", "torrents/setDownloadLimit", form)
+}
+
+// SetTorrentUploadLimit sets the upload limit in bytes/s; 0 removes it.
+func (cli *Client) SetTorrentUploadLimit(ctx context.Context, hashes []string, limit int64) error {
+	form := hashesForm(hashes)
+	form.Set("limit", strconv.FormatInt(limit, 10))
+	return cli.torrentsAction(ctx, "set upload limit of", "torrents/setUploadLimit", form)
+}
+
+func (cli *Client) torrentRateLimits(ctx context.Context, path string, hashes []string) (map[string]int64, error) {
+	limits := map[string]int64{}
+	if err := cli.PostFormJSON(ctx, path, nil, hashesForm(hashes), &limits, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting torrent rate limits", "path", path, "error", err)
+		return nil, fmt.Errorf("getting torrent rate limits: %w", err)
+	}
+	return limits, nil
+}
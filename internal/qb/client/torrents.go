@@ -0,0 +1,368 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type Torrent struct {
+	Hash                     string  `json:"hash"`
+	InfohashV1               string  `json:"infohash_v1"`
+	InfohashV2               string  `json:"infohash_v2"`
+	Name                     string  `json:"name"`
+	MagnetURI                string  `json:"magnet_uri"`
+	State                    string  `json:"state"`
+	Category                 string  `json:"category"`
+	Tags                     string  `json:"tags"`
+	Tracker                  string  `json:"tracker"`
+	TrackersCount            int     `json:"trackers_count"`
+	SavePath                 string  `json:"save_path"`
+	DownloadPath             string  `json:"download_path"`
+	ContentPath              string  `json:"content_path"`
+	RootPath                 string  `json:"root_path"`
+	Size                     int64   `json:"size"`
+	TotalSize                int64   `json:"total_size"`
+	AmountLeft               int64   `json:"amount_left"`
+	Completed                int64   `json:"completed"`
+	Downloaded               int64   `json:"downloaded"`
+	DownloadedSession        int64   `json:"downloaded_session"`
+	Uploaded                 int64   `json:"uploaded"`
+	UploadedSession          int64   `json:"uploaded_session"`
+	Progress                 float64 `json:"progress"`
+	Ratio                    float64 `json:"ratio"`
+	Availability             float64 `json:"availability"`
+	DlSpeed                  int64   `json:"dlspeed"`
+	UpSpeed                  int64   `json:"upspeed"`
+	DlLimit                  int64   `json:"dl_limit"`
+	UpLimit                  int64   `json:"up_limit"`
+	ETA                      int64   `json:"eta"`
+	NumSeeds                 int     `json:"num_seeds"`
+	NumComplete              int     `json:"num_complete"`
+	NumLeechs                int     `json:"num_leechs"`
+	NumIncomplete            int     `json:"num_incomplete"`
+	Priority                 int     `json:"priority"`
+	RatioLimit               float64 `json:"ratio_limit"`
+	MaxRatio                 float64 `json:"max_ratio"`
+	SeedingTimeLimit         int64   `json:"seeding_time_limit"`
+	MaxSeedingTime           int64   `json:"max_seeding_time"`
+	InactiveSeedingTimeLimit int64   `json:"inactive_seeding_time_limit"`
+	MaxInactiveSeedingTime   int64   `json:"max_inactive_seeding_time"`
+	SeedingTime              int64   `json:"seeding_time"`
+	TimeActive               int64   `json:"time_active"`
+	AddedOn                  int64   `json:"added_on"`
+	CompletionOn             int64   `json:"completion_on"`
+	LastActivity             int64   `json:"last_activity"`
+	SeenComplete             int64   `json:"seen_complete"`
+	AutoTMM                  bool    `json:"auto_tmm"`
+	ForceStart               bool    `json:"force_start"`
+	SeqDl                    bool    `json:"seq_dl"`
+	FirstLastPiecePrio       bool    `json:"f_l_piece_prio"`
+	SuperSeeding             bool    `json:"super_seeding"`
+	Private                  bool    `json:"private"`
+}
+
+// TagList splits the comma separated tags reported by qBittorrent.
+func (t Torrent) TagList() []string {
+	var tags []string
+	for _, tag := range strings.Split(t.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ListTorrentsOptions mirrors the query parameters of torrents/info.
+// Category and Tag are pointers because an empty string is meaningful:
+// it selects torrents without category or tag, respectively.
+type ListTorrentsOptions struct {
+	Filter   string
+	Category *string
+	Tag      *string
+	Sort     string
+	Reverse  bool
+	Limit    int
+	Offset   int
+	Hashes   []string
+}
+
+func (opts ListTorrentsOptions) Values() url.Values {
+	params := url.Values{}
+
+	if opts.Filter != "" {
+		params.Set("filter", opts.Filter)
+	}
+
+	if opts.Category != nil {
+		params.Set("category", *opts.Category)
+	}
+
+	if opts.Tag != nil {
+		params.Set("tag", *opts.Tag)
+	}
+
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+
+	if opts.Reverse {
+		params.Set("reverse", "true")
+	}
+
+	if opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	if opts.Offset != 0 {
+		params.Set("offset", strconv.Itoa(opts.Offset))
+	}
+
+	if len(opts.Hashes) > 0 {
+		params.Set("hashes", strings.Join(opts.Hashes, "|"))
+	}
+
+	return params
+}
+
+func (cli *Client) ListTorrents(ctx context.Context, opts ListTorrentsOptions) ([]Torrent, error) {
+	var torrents []Torrent
+	if err := cli.GetJSON(ctx, "torrents/info", opts.Values(), &torrents, cli.SessionAuth); err != nil {
+		cli.Log.Error("listing torrents", "error", err)
+		return nil, fmt.Errorf("listing torrents: %w", err)
+	}
+
+	cli.Log.Debug("torrents listed", "count", len(torrents))
+	return torrents, nil
+}
+
+type TorrentFile struct {
+	Name    string
+	Content []byte
+}
+
+// AddTorrentsOptions mirrors the fields of torrents/add. Zero values are
+// omitted so qBittorrent applies its own defaults; pointers are used where
+// the zero value is a meaningful setting.
+type AddTorrentsOptions struct {
+	URLs                     []string
+	Files                    []TorrentFile
+	SavePath                 string
+	DownloadPath             string
+	Category                 string
+	Tags                     []string
+	Rename                   string
+	Cookie                   string
+	ContentLayout            string
+	Stopped                  *bool
+	SkipChecking             bool
+	AutoTMM                  *bool
+	SequentialDownload       bool
+	FirstLastPiecePrio       bool
+	UpLimit                  int64
+	DlLimit                  int64
+	RatioLimit               *float64
+	SeedingTimeLimit         *int
+	InactiveSeedingTimeLimit *int
+}
+
+func (opts AddTorrentsOptions) Values() url.Values {
+	fields := url.Values{}
+
+	if len(opts.URLs) > 0 {
+		fields.Set("urls", strings.Join(opts.URLs, "\n"))
+	}
+
+	setString := func(key, value string) {
+		if value != "" {
+			fields.Set(key, value)
+		}
+	}
+	setBool := func(key string, value bool) {
+		if value {
+			fields.Set(key, "true")
+		}
+	}
+
+	setString("savepath", opts.SavePath)
+	setString("downloadPath", opts.DownloadPath)
+	setString("category", opts.Category)
+	setString("tags", strings.Join(opts.Tags, ","))
+	setString("rename", opts.Rename)
+	setString("cookie", opts.Cookie)
+	setString("contentLayout", opts.ContentLayout)
+
+	if opts.Stopped != nil {
+		// qBittorrent 5.x renamed "paused" to "stopped"; older releases ignore the new field and vice versa.
+		fields.Set("stopped", strconv.FormatBool(*opts.Stopped))
+		fields.Set("paused", strconv.FormatBool(*opts.Stopped))
+	}
+
+	if opts.AutoTMM != nil {
+		fields.Set("autoTMM", strconv.FormatBool(*opts.AutoTMM))
+	}
+
+	setBool("skip_checking", opts.SkipChecking)
+	setBool("sequentialDownload", opts.SequentialDownload)
+	setBool("firstLastPiecePrio", opts.FirstLastPiecePrio)
+
+	if opts.UpLimit > 0 {
+		fields.Set("upLimit", strconv.FormatInt(opts.UpLimit, 10))
+	}
+
+	if opts.DlLimit > 0 {
+		fields.Set("dlLimit", strconv.FormatInt(opts.DlLimit, 10))
+	}
+
+	if opts.RatioLimit != nil {
+		fields.Set("ratioLimit", strconv.FormatFloat(*opts.RatioLimit, 'f', -1, 64))
+	}
+
+	if opts.SeedingTimeLimit != nil {
+		fields.Set("seedingTimeLimit", strconv.Itoa(*opts.SeedingTimeLimit))
+	}
+
+	if opts.InactiveSeedingTimeLimit != nil {
+		fields.Set("inactiveSeedingTimeLimit", strconv.Itoa(*opts.InactiveSeedingTimeLimit))
+	}
+
+	return fields
+}
+
+func (cli *Client) AddTorrents(ctx context.Context, opts AddTorrentsOptions) error {
+	if len(opts.URLs) == 0 && len(opts.Files) == 0 {
+		return fmt.Errorf("no torrent files or URLs to add")
+	}
+
+	files := make([]FormFile, 0, len(opts.Files))
+	for _, file := range opts.Files {
+		files = append(files, FormFile{Field: "torrents", FileName: file.Name, Content: file.Content})
+	}
+
+	body, _, err := cli.PostMultipart(ctx, "torrents/add", nil, opts.Values(), files, cli.SessionAuth)
+	if err != nil {
+		cli.Log.Error("adding torrents", "error", err)
+		return fmt.Errorf("adding torrents: %w", err)
+	}
+
+	if msg := strings.TrimSpace(string(body)); strings.HasPrefix(msg, "Fails") {
+		cli.Log.Error("adding torrents rejected", "response", msg)
+		return fmt.Errorf("adding torrents rejected by qBittorrent: %s", msg)
+	}
+
+	cli.Log.Info("torrents added", "urls", len(opts.URLs), "files", len(opts.Files))
+	return nil
+}
+
+// AllTorrents can be passed in place of a hash list to act on every torrent.
+const AllTorrents = "all"
+
+func hashesForm(hashes []string) url.Values {
+	return url.Values{"hashes": {strings.Join(hashes, "|")}}
+}
+
+// idsForm is hashesForm for the endpoints naming the field "id" (setSavePath, setDownloadPath).
+func idsForm(hashes []string) url.Values {
+	return url.Values{"id": {strings.Join(hashes, "|")}}
+}
+
+func (cli *Client) torrentsAction(ctx context.Context, action string, path string, form url.Values) error {
+	hashes := form.Get("hashes")
+	if !form.Has("hashes") {
+		hashes = form.Get("id")
+	}
+	log := cli.Log.With("action", action, "hashes", hashes)
+
+	if hashes == "" {
+		log.Error("no torrents selected")
+		return fmt.Errorf("%s torrents: no torrents selected", action)
+	}
+
+	if _, _, err := cli.PostForm(ctx, path, nil, form, cli.SessionAuth); err != nil {
+		log.Error("torrents action failed", "error", err)
+		return fmt.Errorf("%s torrents: %w", action, err)
+	}
+
+	log.Info("torrents action done")
+	return nil
+}
+
+// StopTorrents pauses torrents, using torrents/stop on WebAPI 2.11+ (qBittorrent 5.x) and torrents/pause before.
+func (cli *Client) StopTorrents(ctx context.Context, hashes []string) error {
+	path := "torrents/pause"
+	if ok, err := cli.WebAPIAtLeast(ctx, "2.11.0"); err != nil {
+		return fmt.Errorf("stop torrents: %w", err)
+	} else if ok {
+		path = "torrents/stop"
+	}
+	return cli.torrentsAction(ctx, "stop", path, hashesForm(hashes))
+}
+
+// StartTorrents resumes torrents, using torrents/start on WebAPI 2.11+ (qBittorrent 5.x) and torrents/resume before.
+func (cli *Client) StartTorrents(ctx context.Context, hashes []string) error {
+	path := "torrents/resume"
+	if ok, err := cli.WebAPIAtLeast(ctx, "2.11.0"); err != nil {
+		return fmt.Errorf("start torrents: %w", err)
+	} else if ok {
+		path = "torrents/start"
+	}
+	return cli.torrentsAction(ctx, "start", path, hashesForm(hashes))
+}
+
+func (cli *Client) RecheckTorrents(ctx context.Context, hashes []string) error {
+	return cli.torrentsAction(ctx, "recheck", "torrents/recheck", hashesForm(hashes))
+}
+
+func (cli *Client) ReannounceTorrents(ctx context.Context, hashes []string) error {
+	return cli.torrentsAction(ctx, "reannounce", "torrents/reannounce", hashesForm(hashes))
+}
+
+func (cli *Client) SetForceStart(ctx context.Context, hashes []string, value bool) error {
+	form := hashesForm(hashes)
+	form.Set("value", strconv.FormatBool(value))
+	return cli.torrentsAction(ctx, "force start", "torrents/setForceStart", form)
+}
+
+func (cli *Client) DeleteTorrents(ctx context.Context, hashes []string, deleteFiles bool) error {
+	form := hashesForm(hashes)
+	form.Set("deleteFiles", strconv.FormatBool(deleteFiles))
+	return cli.torrentsAction(ctx, "delete", "torrents/delete", form)
+}
+
+// SetLocation moves the torrents' data to location. qBittorrent disables automatic management for them.
+func (cli *Client) SetLocation(ctx context.Context, hashes []string, location string) error {
+	form := hashesForm(hashes)
+	form.Set("location", location)
+	return cli.torrentsAction(ctx, "set location of", "torrents/setLocation", form)
+}
+
+func (cli *Client) SetSavePath(ctx context.Context, hashes []string, path string) error {
+	form := idsForm(hashes)
+	form.Set("path", path)
+	return cli.torrentsAction(ctx, "set save path of", "torrents/setSavePath", form)
+}
+
+func (cli *Client) SetDownloadPath(ctx context.Context, hashes []string, path string) error {
+	form := idsForm(hashes)
+	form.Set("path", path)
+	return cli.torrentsAction(ctx, "set download path of", "torrents/setDownloadPath", form)
+}
+
+func (cli *Client) SetAutoManagement(ctx context.Context, hashes []string, enable bool) error {
+	form := hashesForm(hashes)
+	form.Set("enable", strconv.FormatBool(enable))
+	return cli.torrentsAction(ctx, "set automatic management of", "torrents/setAutoManagement", form)
+}
+
+// ExportTorrent returns the .torrent file of a torrent. It fails for magnet links whose metadata is not known yet.
+func (cli *Client) ExportTorrent(ctx context.Context, hash string) ([]byte, error) {
+	params := url.Values{"hash": {hash}}
+	body, _, err := cli.Get(ctx, "torrents/export", params, nil, cli.SessionAuth)
+	if err != nil {
+		cli.Log.Error("exporting torrent", "hash", hash, "error", err)
+		return nil, fmt.Errorf("exporting torrent: %w", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type Tracker struct {
+	URL           string `json:"url"`
+	Status        int    `json:"status"`
+	Tier          int    `json:"tier"`
+	NumPeers      int    `json:"num_peers"`
+	NumSeeds      int    `json:"num_seeds"`
+	NumLeeches    int    `json:"num_leeches"`
+	NumDownloaded int    `json:"num_downloaded"`
+	Msg           string `json:"msg"`
+}
+
+// IsPseudo reports whether the entry stands for DHT, PeX or LSD rather than an actual tracker.
+func (t Tracker) IsPseudo() bool {
+	return strings.HasPrefix(t.URL, "** [")
+}
+
+func (t Tracker) StatusString() string {
+	switch t.Status {
+	case 0:
+		return "disabled"
+	case 1:
+		return "not contacted"
+	case 2:
+		return "working"
+	case 3:
+		return "updating"
+	case 4:
+		return "not working"
+	default:
+		return fmt.Sprintf("unknown (%d)", t.Status)
+	}
+}
+
+func (cli *Client) Trackers(ctx context.Context, hash string) ([]Tracker, error) {
+	var trackers []Tracker
+	params := url.Values{"hash": {hash}}
+	if err := cli.GetJSON(ctx, "torrents/trackers", params, &trackers, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting trackers", "hash", hash, "error", err)
+		return nil, fmt.Errorf("getting trackers: %w", err)
+	}
+	return trackers, nil
+}
+
+func (cli *Client) AddTrackers(ctx context.Context, hash string, urls []string) error {
+	form := url.Values{
+		"hash": {hash},
+		"urls": {strings.Join(urls, "\n")},
+	}
+
+	if _, _, err := cli.PostForm(ctx, "torrents/addTrackers", nil, form, cli.SessionAuth); err != nil {
+		cli.Log.Error("adding trackers", "hash", hash, "error", err)
+		return fmt.Errorf("adding trackers: %w", err)
+	}
+
+	cli.Log.Info("trackers added", "hash", hash, "urls", redactTrackerURLs(urls))
+	return nil
+}
+
+func (cli *Client) EditTracker(ctx context.Context, hash string, origURL string, newURL string) error {
+	form := url.Values{
+		"hash":    {hash},
+		"origUrl": {origURL},
+		"newUrl":  {newURL},
+	}
+
+	if _, _, err := cli.PostForm(ctx, "torrents/editTracker", nil, form, cli.SessionAuth); err != nil {
+		cli.Log.Error("editing tracker", "hash", hash, "origUrl", redactTrackerURL(origURL), "newUrl", redactTrackerURL(newURL), "error", err)
+		return fmt.Errorf("editing tracker: %w", err)
+	}
+
+	cli.Log.Info("tracker edited", "hash", hash, "origUrl", redactTrackerURL(origURL), "newUrl", redactTrackerURL(newURL))
+	return nil
+}
+
+func (cli *Client) RemoveTrackers(ctx context.Context, hash string, urls []string) error {
+	form := url.Values{
+		"hash": {hash},
+		"urls": {strings.Join(urls, "|")},
+	}
+
+	if _, _, err := cli.PostForm(ctx, "torrents/removeTrackers", nil, form, cli.SessionAuth); err != nil {
+		cli.Log.Error("removing trackers", "hash", hash, "error", err)
+		return fmt.Errorf("removing trackers: %w", err)
+	}
+
+	cli.Log.Info("trackers removed", "hash", hash, "urls", redactTrackerURLs(urls))
+	return nil
+}
+
+// redactTrackerURL keeps only the scheme and host of a tracker URL for logging, since
+// private trackers put the passkey in the path or query.
+func redactTrackerURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "<redacted>"
+	}
+	if u.Path == "" && u.RawQuery == "" && u.User == nil {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host + "/..."
+}
+
+func redactTrackerURLs(urls []string) []string {
+	redacted := make([]string, len(urls))
+	for i, u := range urls {
+		redacted[i] = redactTrackerURL(u)
+	}
+	return redacted
+}
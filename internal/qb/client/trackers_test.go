@@ -0,0 +1,17 @@
+package client
+
+import "testing"
+
+func TestRedactTrackerURL(t *testing.T) {
+	tests := map[string]string{
+		"https://tracker.example.org/a1b2c3d4/announce":    "https://tracker.example.org/...",
+		"http://tracker.example.org:8080/announce?pk=1234": "http://tracker.example.org:8080/...",
+		"udp://open.example.org:1337":                      "udp://open.example.org:1337",
+		"not a url":                                        "<redacted>",
+	}
+	for in, want := range tests {
+		if got := redactTrackerURL(in); got != want {
+			t.Errorf("redactTrackerURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type TransferInfo struct {
+	DlInfoSpeed      int64  `json:"dl_info_speed"`
+	DlInfoData       int64  `json:"dl_info_data"`
+	UpInfoSpeed      int64  `json:"up_info_speed"`
+	UpInfoData       int64  `json:"up_info_data"`
+	DlRateLimit      int64  `json:"dl_rate_limit"`
+	UpRateLimit      int64  `json:"up_rate_limit"`
+	DHTNodes         int    `json:"dht_nodes"`
+	ConnectionStatus string `json:"connection_status"`
+}
+
+func (cli *Client) TransferInfo(ctx context.Context) (*TransferInfo, error) {
+	var info TransferInfo
+	if err := cli.GetJSON(ctx, "transfer/info", nil, &info, cli.SessionAuth); err != nil {
+		cli.Log.Error("getting transfer info", "error", err)
+		return nil, fmt.Errorf("getting transfer info: %w", err)
+	}
+	return &info, nil
+}
+
+// AltSpeedLimitsEnabled reports whether the alternative speed limits are active.
+func (cli *Client) AltSpeedLimitsEnabled(ctx context.Context) (bool, error) {
+	body, _, err := cli.Get(ctx, "transfer/speedLimitsMode", nil, nil, cli.SessionAuth)
+	if err != nil {
+		cli.Log.Error("getting speed limits mode", "error", err)
+		return false, fmt.Errorf("getting speed limits mode: %w", err)
+	}
+	return strings.TrimSpace(string(body)) == "1", nil
+}
+
+func (cli *Client) ToggleSpeedLimitsMode(ctx context.Context) error {
+	if _, _, err := cli.Post(ctx, "transfer/toggleSpeedLimitsMode", nil, nil, cli.SessionAuth); err != nil {
+		cli.Log.Error("toggling speed limits mode", "error", err)
+		return fmt.Errorf("toggling speed limits mode: %w", err)
+	}
+
+	cli.Log.Info("speed limits mode toggled")
+	return nil
+}
+
+// SetAltSpeedLimits enables or disables the alternative speed limits, toggling only when needed.
+func (cli *Client) SetAltSpeedLimits(ctx context.Context, enabled bool) error {
+	current, err := cli.AltSpeedLimitsEnabled(ctx)
+	if err != nil {
+		return err
+	}
+
+	if current == enabled {
+		cli.Log.Debug("speed limits mode unchanged", "alt", enabled)
+		return nil
+	}
+	return cli.ToggleSpeedLimitsMode(ctx)
+}
+
+func (cli *Client) DownloadLimit(ctx context.Context) (int64, error) {
+	return cli.getRateLimit(ctx, "transfer/downloadLimit")
+}
+
+func (cli *Client) UploadLimit(ctx context.Context) (int64, error) {
+	return cli.getRateLimit(ctx, "transfer/uploadLimit")
+}
+
+// SetDownloadLimit sets the global download limit in bytes/second (0 for unlimited).
+func (cli *Client) SetDownloadLimit(ctx context.Context, limit int64) error {
+	return cli.setRateLimit(ctx, "transfer/setDownloadLimit", limit)
+}
+
+// SetUploadLimit sets the global upload limit in bytes/second (0 for unlimited).
+func (cli *Client) SetUploadLimit(ctx context.Context, limit int64) error {
+	return cli.setRateLimit(ctx, "transfer/setUploadLimit", limit)
+}
+
+func (cli *Client) getRateLimit(ctx context.Context, path string) (int64, error) {
+	body, _, err := cli.Get(ctx, path, nil, nil, cli.SessionAuth)
+	if err != nil {
+		cli.Log.Error("getting rate limit", "endpoint", path, "error", err)
+		return 0, fmt.Errorf("getting rate limit: %w", err)
+	}
+
+	limit, err := strconv.ParseInt(strings.TrimSpace(string(body)), 10, 64)
+	if err != nil {
+		cli.Log.Error("invalid rate limit", "endpoint", path, "body", string(body))
+		return 0, fmt.Errorf("invalid rate limit: %s", body)
+	}
+	return limit, nil
+}
+
+func (cli *Client) setRateLimit(ctx context.Context, path string, limit int64) error {
+	if limit < 0 {
+		return fmt.Errorf("invalid rate limit: %d", limit)
+	}
+
+	form := url.Values{"limit": {strconv.FormatInt(limit, 10)}}
+	if _, _, err := cli.PostForm(ctx, path, nil, form, cli.SessionAuth); err != nil {
+		cli.Log.Error("setting rate limit", "endpoint", path, "limit", limit, "error", err)
+		return fmt.Errorf("setting rate limit: %w", err)
+	}
+
+	cli.Log.Info("rate limit set", "endpoint", path, "limit", limit)
+	return nil
+}
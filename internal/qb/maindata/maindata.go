@@ -0,0 +1,363 @@
+package maindata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+)
+
+type EventType string
+
+const (
+	TorrentAdded        EventType = "added"
+	TorrentRemoved      EventType = "removed"
+	TorrentStateChanged EventType = "state_changed"
+	TorrentCompleted    EventType = "completed"
+)
+
+type Event struct {
+	Type      EventType       `json:"type"`
+	Time      time.Time       `json:"time"`
+	Hash      string          `json:"hash"`
+	Name      string          `json:"name"`
+	State     string          `json:"state,omitempty"`
+	PrevState string          `json:"prev_state,omitempty"`
+	Torrent   *client.Torrent `json:"torrent,omitempty"`
+}
+
+type rawObject = map[string]json.RawMessage
+
+// Sync keeps an in-memory copy of qBittorrent's state by polling sync/maindata with the rid
+// protocol and merging the partial updates. Changes to torrents are published as events.
+// The first (full) update only seeds the state and does not produce events.
+type Sync struct {
+	cli      *client.Client
+	interval time.Duration
+	rid      int
+	synced   bool
+	events   chan Event
+	Log      *slog.Logger
+
+	mu          sync.RWMutex
+	torrents    map[string]rawObject
+	categories  map[string]rawObject
+	tags        map[string]struct{}
+	serverState rawObject
+}
+
+type Option func(*Sync)
+
+const defaultInterval = 2 * time.Second
+const defaultBufferSize = 64
+const maxRetryDelay = time.Minute
+
+func New(cli *client.Client, opts ...Option) *Sync {
+	s := &Sync{
+		cli:         cli,
+		interval:    defaultInterval,
+		events:      make(chan Event, defaultBufferSize),
+		Log:         cli.Log,
+		torrents:    map[string]rawObject{},
+		categories:  map[string]rawObject{},
+		tags:        map[string]struct{}{},
+		serverState: rawObject{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func WithInterval(interval time.Duration) Option {
+	return func(s *Sync) {
+		if interval > 0 {
+			s.interval = interval
+		}
+	}
+}
+
+func WithBufferSize(size int) Option {
+	return func(s *Sync) {
+		s.events = make(chan Event, size)
+	}
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Sync) {
+		s.Log = logger
+	}
+}
+
+// Events returns the channel events are published on by Run. It is closed when Run returns.
+func (s *Sync) Events() <-chan Event {
+	return s.events
+}
+
+// Run polls until the context is done, publishing events on the Events channel.
+func (s *Sync) Run(ctx context.Context) error {
+	defer close(s.events)
+
+	return s.Follow(ctx, func(events []Event) error {
+		for _, event := range events {
+			select {
+			case s.events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+}
+
+// Follow polls until the context is done or handle fails, calling handle with the events of
+// every successful poll (possibly none).
+// A failed poll is logged and retried with a doubling delay (up to maxRetryDelay); the next poll
+// asks for a full update, so that changes missed in between still produce events.
+func (s *Sync) Follow(ctx context.Context, handle func(events []Event) error) error {
+	retryDelay := s.interval
+	for {
+		wait := s.interval
+
+		events, err := s.Poll(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			s.Log.Warn("main data poll failed", "retry_in", retryDelay, "error", err)
+			s.resync()
+			wait = retryDelay
+			retryDelay = min(2*retryDelay, max(maxRetryDelay, s.interval))
+		default:
+			retryDelay = s.interval
+			if err := handle(events); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// resync makes the next poll request a full update.
+func (s *Sync) resync() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rid = 0
+}
+
+// Poll performs a single sync/maindata round, merges it into the state and returns the resulting events.
+func (s *Sync) Poll(ctx context.Context) ([]Event, error) {
+	data, err := s.cli.SyncMainData(ctx, s.rid)
+	if err != nil {
+		return nil, fmt.Errorf("polling main data: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := s.merge(data, time.Now())
+	s.rid = data.RID
+	s.synced = true
+
+	s.Log.Debug("main data merged", "rid", s.rid, "torrents", len(s.torrents), "events", len(events))
+	return events, nil
+}
+
+func (s *Sync) merge(data *client.MainData, now time.Time) []Event {
+	emit := s.synced
+	var events []Event
+
+	prevTorrents := s.torrents
+	if data.FullUpdate {
+		s.torrents = map[string]rawObject{}
+		s.categories = map[string]rawObject{}
+		s.tags = map[string]struct{}{}
+		s.serverState = rawObject{}
+	}
+
+	for hash, fields := range data.Torrents {
+		prev, existed := prevTorrents[hash]
+		merged := rawObject{}
+		if existed && !data.FullUpdate {
+			for k, v := range prev {
+				merged[k] = v
+			}
+		}
+		for k, v := range fields {
+			merged[k] = v
+		}
+		s.torrents[hash] = merged
+
+		if !emit {
+			continue
+		}
+
+		cur := decodeTorrent(hash, merged)
+		if !existed {
+			events = append(events, newEvent(TorrentAdded, now, cur, ""))
+			continue
+		}
+
+		old := decodeTorrent(hash, prev)
+		if old.State != cur.State {
+			events = append(events, newEvent(TorrentStateChanged, now, cur, old.State))
+		}
+		if old.Progress < 1 && cur.Progress >= 1 {
+			events = append(events, newEvent(TorrentCompleted, now, cur, ""))
+		}
+	}
+
+	removed := data.TorrentsRemoved
+	if data.FullUpdate {
+		// A full update replaces the state, so anything missing from it is gone
+		removed = nil
+		for hash := range prevTorrents {
+			if _, ok := s.torrents[hash]; !ok {
+				removed = append(removed, hash)
+			}
+		}
+		sort.Strings(removed)
+	}
+
+	for _, hash := range removed {
+		prev, existed := prevTorrents[hash]
+		delete(s.torrents, hash)
+		if emit && existed {
+			events = append(events, newEvent(TorrentRemoved, now, decodeTorrent(hash, prev), ""))
+		}
+	}
+
+	for name, fields := range data.Categories {
+		merged := s.categories[name]
+		if merged == nil {
+			merged = rawObject{}
+		}
+		for k, v := range fields {
+			merged[k] = v
+		}
+		s.categories[name] = merged
+	}
+	for _, name := range data.CategoriesRemoved {
+		delete(s.categories, name)
+	}
+
+	for _, tag := range data.Tags {
+		s.tags[tag] = struct{}{}
+	}
+	for _, tag := range data.TagsRemoved {
+		delete(s.tags, tag)
+	}
+
+	for k, v := range data.ServerState {
+		s.serverState[k] = v
+	}
+
+	return events
+}
+
+func newEvent(eventType EventType, now time.Time, t client.Torrent, prevState string) Event {
+	return Event{
+		Type:      eventType,
+		Time:      now,
+		Hash:      t.Hash,
+		Name:      t.Name,
+		State:     t.State,
+		PrevState: prevState,
+		Torrent:   &t,
+	}
+}
+
+func decodeTorrent(hash string, fields rawObject) client.Torrent {
+	var t client.Torrent
+	if data, err := json.Marshal(fields); err == nil {
+		_ = json.Unmarshal(data, &t)
+	}
+	// Torrents are keyed by hash in sync/maindata, so the field itself is usually absent
+	t.Hash = hash
+	return t
+}
+
+// RID returns the response id of the last merged update.
+func (s *Sync) RID() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rid
+}
+
+// Torrents returns a snapshot of the known torrents, sorted by name.
+func (s *Sync) Torrents() []client.Torrent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	torrents := make([]client.Torrent, 0, len(s.torrents))
+	for hash, fields := range s.torrents {
+		torrents = append(torrents, decodeTorrent(hash, fields))
+	}
+	sort.Slice(torrents, func(i, j int) bool {
+		if torrents[i].Name != torrents[j].Name {
+			return torrents[i].Name < torrents[j].Name
+		}
+		return torrents[i].Hash < torrents[j].Hash
+	})
+	return torrents
+}
+
+func (s *Sync) Torrent(hash string) (client.Torrent, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fields, ok := s.torrents[hash]
+	if !ok {
+		return client.Torrent{}, false
+	}
+	return decodeTorrent(hash, fields), true
+}
+
+func (s *Sync) Categories() map[string]client.Category {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	categories := make(map[string]client.Category, len(s.categories))
+	for name, fields := range s.categories {
+		var category client.Category
+		if data, err := json.Marshal(fields); err == nil {
+			_ = json.Unmarshal(data, &category)
+		}
+		category.Name = name
+		categories[name] = category
+	}
+	return categories
+}
+
+func (s *Sync) Tags() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tags := make([]string, 0, len(s.tags))
+	for tag := range s.tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func (s *Sync) ServerState() client.ServerState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var state client.ServerState
+	if data, err := json.Marshal(s.serverState); err == nil {
+		_ = json.Unmarshal(data, &state)
+	}
+	return state
+}
@@ -0,0 +1,174 @@
+package maindata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gstos/qbcli/internal/qb/qbtest"
+)
+
+// responses maps the rid of each sync/maindata request to its reply.
+var responses = map[string]string{
+	"0": `{"rid":1,"full_update":true,
+		"torrents":{"aaa":{"name":"alpha","state":"downloading","progress":0.5},"bbb":{"name":"beta","state":"uploading","progress":1}},
+		"categories":{"linux":{"name":"linux","savePath":"/data/linux"}},
+		"tags":["iso"],
+		"server_state":{"connection_status":"connected","dht_nodes":10}}`,
+	"1": `{"rid":2,
+		"torrents":{"aaa":{"state":"uploading","progress":1},"ccc":{"name":"gamma","state":"metaDL","progress":0}},
+		"torrents_removed":["bbb"],
+		"tags":["distro"],
+		"server_state":{"dht_nodes":12}}`,
+	"2": `{"rid":3}`,
+}
+
+func newTestSync(t *testing.T, opts ...Option) *Sync {
+	t.Helper()
+	return newTestSyncWith(t, func(rid string) (string, bool) {
+		body, ok := responses[rid]
+		return body, ok
+	}, opts...)
+}
+
+// newTestSyncWith serves sync/maindata through respond, which fails the request when it returns false.
+func newTestSyncWith(t *testing.T, respond func(rid string) (string, bool), opts ...Option) *Sync {
+	t.Helper()
+
+	server := qbtest.NewServer(t)
+	server.HandleFunc("sync/maindata", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := respond(r.URL.Query().Get("rid"))
+		if !ok {
+			http.Error(w, "unexpected rid", http.StatusBadRequest)
+			return
+		}
+		_, _ = fmt.Fprint(w, body)
+	})
+	return New(server.Client(t), opts...)
+}
+
+func TestPollMergesPartialUpdates(t *testing.T) {
+	s := newTestSync(t)
+	ctx := context.Background()
+
+	events, err := s.Poll(ctx)
+	if err != nil {
+		t.Fatalf("first poll: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("first poll produced %d events, want none", len(events))
+	}
+	if got := len(s.Torrents()); got != 2 {
+		t.Fatalf("after first poll got %d torrents, want 2", got)
+	}
+
+	events, err = s.Poll(ctx)
+	if err != nil {
+		t.Fatalf("second poll: %v", err)
+	}
+
+	want := map[EventType]string{
+		TorrentAdded:        "ccc",
+		TorrentRemoved:      "bbb",
+		TorrentStateChanged: "aaa",
+		TorrentCompleted:    "aaa",
+	}
+	if len(events) != len(want) {
+		t.Fatalf("second poll produced %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for _, event := range events {
+		if hash, ok := want[event.Type]; !ok || hash != event.Hash {
+			t.Errorf("unexpected event %s for %s", event.Type, event.Hash)
+		}
+	}
+
+	alpha, ok := s.Torrent("aaa")
+	if !ok {
+		t.Fatal("torrent aaa missing after partial update")
+	}
+	if alpha.Name != "alpha" || alpha.State != "uploading" {
+		t.Errorf("partial update not merged: name=%q state=%q", alpha.Name, alpha.State)
+	}
+
+	if _, ok := s.Torrent("bbb"); ok {
+		t.Error("removed torrent bbb still present")
+	}
+
+	if tags := s.Tags(); len(tags) != 2 {
+		t.Errorf("got tags %v, want [distro iso]", tags)
+	}
+
+	if state := s.ServerState(); state.DHTNodes != 12 || state.ConnectionStatus != "connected" {
+		t.Errorf("server state not merged: %+v", state)
+	}
+
+	if category := s.Categories()["linux"]; category.SavePath != "/data/linux" {
+		t.Errorf("got category %+v", category)
+	}
+
+	events, err = s.Poll(ctx)
+	if err != nil {
+		t.Fatalf("third poll: %v", err)
+	}
+	if len(events) != 0 || s.RID() != 3 {
+		t.Errorf("empty update produced %d events, rid %d", len(events), s.RID())
+	}
+}
+
+func TestRunResyncsAfterError(t *testing.T) {
+	var mu sync.Mutex
+	fullUpdates := 0
+	s := newTestSyncWith(t, func(rid string) (string, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch rid {
+		case "0":
+			fullUpdates++
+			if fullUpdates == 1 {
+				return `{"rid":1,"full_update":true,"torrents":{"aaa":{"name":"alpha","state":"downloading"}}}`, true
+			}
+			return `{"rid":5,"full_update":true,"torrents":{"aaa":{"name":"alpha","state":"uploading"},"ccc":{"name":"gamma","state":"metaDL"}}}`, true
+		case "5":
+			return `{"rid":6}`, true
+		}
+		// Anything else fails, as if qBittorrent restarted and lost track of the rid
+		return "", false
+	}, WithInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	want := map[EventType]string{
+		TorrentStateChanged: "aaa",
+		TorrentAdded:        "ccc",
+	}
+	for len(want) > 0 {
+		select {
+		case event, ok := <-s.Events():
+			if !ok {
+				t.Fatalf("events closed early: %v", <-done)
+			}
+			if hash, ok := want[event.Type]; !ok || hash != event.Hash {
+				t.Fatalf("unexpected event %s for %s", event.Type, event.Hash)
+			}
+			delete(want, event.Type)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("missing events after resync: %v", want)
+		}
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() = %v, want context.Canceled", err)
+	}
+	if _, ok := <-s.Events(); ok {
+		t.Error("events not closed after Run returned")
+	}
+}
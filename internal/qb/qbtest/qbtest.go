@@ -0,0 +1,137 @@
+// Package qbtest runs a fake qBittorrent Web API for tests.
+package qbtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/gstos/qbcli/internal/qb/client"
+	"github.com/gstos/qbcli/internal/qb/credentials"
+)
+
+// Server is a fake qBittorrent Web API. Logins always succeed; other endpoints answer
+// 404 unless registered with HandleFunc. It is closed when the test ends.
+type Server struct {
+	*httptest.Server
+	mux *http.ServeMux
+}
+
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "SID", Value: "test"})
+		_, _ = fmt.Fprint(w, "Ok.")
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return &Server{Server: server, mux: mux}
+}
+
+// HandleFunc registers the handler of an API path, such as "sync/maindata".
+func (s *Server) HandleFunc(path string, handler http.HandlerFunc) {
+	s.mux.HandleFunc("/api/v2/"+path, handler)
+}
+
+// Client returns a client of the server. Requests are attempted once, so that failures reach
+// the caller instead of being retried; opts may override it.
+func (s *Server) Client(t testing.TB, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	hostURL, _ := url.Parse(s.URL)
+	creds, err := credentials.FromURL(hostURL, credentials.WithUsername("admin"), credentials.WithPassword("secret"))
+	if err != nil {
+		t.Fatalf("creating credentials: %v", err)
+	}
+	return client.New(creds, append([]client.Option{client.WithRetry(1, 0)}, opts...)...)
+}
+
+// Preferences serves app/preferences and app/setPreferences from the given values, and
+// returns them for inspection.
+func (s *Server) Preferences(values map[string]any) *Preferences {
+	p := &Preferences{values: map[string]any{}}
+	for k, v := range values {
+		p.Set(k, v)
+	}
+
+	s.HandleFunc("app/preferences", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(p.values)
+	})
+
+	s.HandleFunc("app/setPreferences", func(w http.ResponseWriter, r *http.Request) {
+		var changes map[string]any
+		if err := json.Unmarshal([]byte(r.FormValue("json")), &changes); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.writes++
+		if p.ignoreWrites {
+			return
+		}
+		for k, v := range changes {
+			p.values[k] = v
+		}
+	})
+
+	return p
+}
+
+// Preferences holds the preferences of a Server. Values are kept as decoded from JSON,
+// so numbers are float64.
+type Preferences struct {
+	mu           sync.Mutex
+	values       map[string]any
+	writes       int
+	ignoreWrites bool
+}
+
+func (p *Preferences) Get(key string) any {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.values[key]
+}
+
+// Int returns a numeric preference, or -1 if it is missing or not a number.
+func (p *Preferences) Int(key string) int {
+	if v, ok := p.Get(key).(float64); ok {
+		return int(v)
+	}
+	return -1
+}
+
+func (p *Preferences) Set(key string, value any) {
+	// Round-trip through JSON so that values look like the ones written by clients
+	data, _ := json.Marshal(value)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var decoded any
+	_ = json.Unmarshal(data, &decoded)
+	p.values[key] = decoded
+}
+
+// Writes returns the number of app/setPreferences requests received.
+func (p *Preferences) Writes() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.writes
+}
+
+// IgnoreWrites makes app/setPreferences accept changes without applying them.
+func (p *Preferences) IgnoreWrites(ignore bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ignoreWrites = ignore
+}
@@ -0,0 +1,144 @@
+package units
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// InfiniteETA is the sentinel qBittorrent reports when no ETA can be estimated.
+const InfiniteETA = 8640000
+
+var iecUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+func FormatBytes(n int64) string {
+	if n < 0 {
+		return "-"
+	}
+
+	value := float64(n)
+	unit := 0
+	for value >= 1024 && unit < len(iecUnits)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%d %s", n, iecUnits[unit])
+	}
+	return fmt.Sprintf("%.1f %s", value, iecUnits[unit])
+}
+
+func FormatRate(n int64) string {
+	return FormatBytes(n) + "/s"
+}
+
+func FormatETA(seconds int64) string {
+	if seconds < 0 || seconds >= InfiniteETA {
+		return "∞"
+	}
+	return FormatDuration(time.Duration(seconds) * time.Second)
+}
+
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%02dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm%02ds", minutes, seconds)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
+
+func FormatPercent(ratio float64) string {
+	return fmt.Sprintf("%.1f%%", ratio*100)
+}
+
+var byteMultipliers = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1000,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1000 * 1000,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1000 * 1000 * 1000,
+	"gib": 1 << 30,
+	"t":   1 << 40,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"tib": 1 << 40,
+}
+
+// ParseBytes parses sizes such as "512", "1.5GiB" or "700 MB".
+// IEC units (KiB, MiB...) and bare letters (K, M...) are powers of 1024; SI units (kB, MB...) powers of 1000.
+func ParseBytes(s string) (int64, error) {
+	value := strings.TrimSpace(s)
+
+	split := strings.IndexFunc(value, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if split < 0 {
+		split = len(value)
+	}
+
+	number, unit := value[:split], strings.ToLower(strings.TrimSpace(value[split:]))
+	multiplier, ok := byteMultipliers[unit]
+	if !ok || number == "" {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// ParseRate parses rates such as "5MiB", "1.5 MB/s", "5MBps" or "800K" into bytes per second.
+// Bit rates, spelled with a lowercase b such as "8Mbps", are divided by 8, their prefixes being SI
+// (kbps is 1000 bits per second). "0", "unlimited" and "none" mean no limit and yield 0.
+func ParseRate(s string) (int64, error) {
+	value := strings.TrimSpace(s)
+	switch strings.ToLower(value) {
+	case "unlimited", "none", "off":
+		return 0, nil
+	}
+
+	if bits, ok := strings.CutSuffix(value, "bps"); ok {
+		// Reuse the byte units: "k" + "b" is the SI kilo, "ki" + "b" the IEC one
+		rate, err := ParseBytes(bits + "b")
+		if err != nil {
+			return 0, fmt.Errorf("invalid rate: %q", s)
+		}
+		return rate / 8, nil
+	}
+
+	value = strings.ToLower(value)
+	if bytes, ok := strings.CutSuffix(value, "bps"); ok {
+		value = bytes + "b"
+	}
+	value = strings.TrimSuffix(value, "/s")
+
+	rate, err := ParseBytes(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rate: %q", s)
+	}
+	return rate, nil
+}
@@ -0,0 +1,63 @@
+package units
+
+import "testing"
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"0", 0},
+		{"unlimited", 0},
+		{"512", 512},
+		{"5MiB", 5 << 20},
+		{"5M", 5 << 20},
+		{"1.5 MB/s", 1500000},
+		{"800KiB/s", 800 << 10},
+		{"2kbps", 250},
+		{"8 Mbps", 1000000},
+		{"800bps", 100},
+		{"8Mbps", 1000000},
+		{"5MBps", 5000000},
+		{"1MiBps", 1 << 20},
+		{"100KBps", 100000},
+		{"1GiB", 1 << 30},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRate(tt.in)
+		if err != nil {
+			t.Errorf("ParseRate(%q) unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRate(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseRateInvalid(t *testing.T) {
+	for _, in := range []string{"", "fast", "5XB", "-1M", "MiB", "kbps", "5Xbps"} {
+		if _, err := ParseRate(in); err == nil {
+			t.Errorf("ParseRate(%q) expected error", in)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{5 << 20, "5.0 MiB"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatBytes(tt.in); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}